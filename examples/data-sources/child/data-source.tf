@@ -0,0 +1,53 @@
+terraform {
+  required_providers {
+    context = {
+      source = "registry.terraform.io/cloudposse/context"
+    }
+  }
+}
+
+provider "context" {
+  properties = {
+    namespace  = { order = 1 }
+    tenant     = { order = 2 }
+    stage      = { order = 3 }
+    name       = { order = 4 }
+    attributes = { order = 5 }
+  }
+
+  values = {
+    "namespace" = "cp"
+    "tenant"    = "core"
+    "stage"     = "prod"
+  }
+}
+
+data "context_child" "component" {
+  values = {
+    "name" = "api"
+  }
+}
+
+data "context_child" "worker" {
+  context = data.context_child.component.encoded
+
+  values = {
+    "attributes" = "worker"
+  }
+}
+
+data "context_label" "worker" {
+  context = data.context_child.worker.encoded
+}
+
+data "context_tags" "component" {
+  context = data.context_child.component.encoded
+}
+
+output "worker_label" {
+  value = data.context_label.worker.rendered
+}
+
+output "component_tags" {
+  value = data.context_tags.component.tags
+}
@@ -33,3 +33,17 @@ locals {
 output "values" {
   value = local.context.values.Namespace
 }
+
+# Pass the context to a module that still uses the null-label context.tf
+module "label" {
+  source  = "cloudposse/label/null"
+  version = "0.25.0"
+
+  context = data.context_config.example.null_label_context
+}
+
+# Share the context with other root modules, which rebuild it with
+# provider "context" { context_token = data.terraform_remote_state.core.outputs.context_token }
+output "context_token" {
+  value = data.context_config.example.encoded
+}
@@ -0,0 +1,51 @@
+terraform {
+  required_providers {
+    context = {
+      source = "registry.terraform.io/cloudposse/context"
+    }
+  }
+}
+
+provider "context" {
+  properties = {
+    namespace   = {}
+    tenant      = {}
+    stage       = {}
+    environment = {}
+    name        = {}
+  }
+
+  property_order = ["namespace", "tenant", "stage", "environment", "name"]
+
+  label_formats = {
+    stack = {
+      template = "{{.tenant}}-{{.environment}}-{{.stage}}"
+    }
+    account_name = {
+      properties = ["tenant", "stage"]
+      delimiter  = "-"
+    }
+  }
+
+  values = {
+    "namespace"   = "cp"
+    "tenant"      = "core"
+    "stage"       = "prod"
+    "environment" = "ue1"
+    "name"        = "example"
+  }
+}
+
+data "context_label" "stack" {
+  format = "stack"
+}
+
+data "context_config" "example" {}
+
+output "stack" {
+  value = data.context_label.stack.rendered
+}
+
+output "account_name" {
+  value = data.context_config.example.label_formats["account_name"]
+}
@@ -0,0 +1,35 @@
+terraform {
+  required_providers {
+    context = {
+      source = "registry.terraform.io/cloudposse/context"
+    }
+  }
+}
+
+provider "context" {
+  properties = {
+    namespace = { order = 1 }
+    region    = { order = 2, label_lookup = "aws_region_short" }
+    name      = { order = 3 }
+  }
+
+  values = {
+    "namespace" = "cp"
+    "region"    = "us-west-2"
+    "name"      = "api"
+  }
+}
+
+data "context_lookup" "aws_regions" {
+  name = "aws_region_short"
+}
+
+data "context_label" "api" {}
+
+output "region_codes" {
+  value = data.context_lookup.aws_regions.values
+}
+
+output "api_label" {
+  value = data.context_label.api.rendered
+}
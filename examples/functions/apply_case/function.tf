@@ -0,0 +1,4 @@
+output "cased" {
+  # Returns "HelloWorld"
+  value = provider::context::apply_case("hello_world", "title")
+}
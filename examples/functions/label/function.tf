@@ -0,0 +1,22 @@
+data "context_config" "this" {}
+
+output "bucket_name" {
+  value = provider::context::label(data.context_config.this.encoded, {
+    properties = ["namespace", "stage", "name"]
+    values = {
+      name = "artifacts"
+    }
+  })
+}
+
+output "path" {
+  value = provider::context::label(data.context_config.this.encoded, {
+    template = "{{.namespace}}/{{.stage}}/{{.name}}"
+  })
+}
+
+output "stack" {
+  value = provider::context::label(data.context_config.this.encoded, {
+    format = "stack"
+  })
+}
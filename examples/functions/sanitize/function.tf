@@ -0,0 +1,4 @@
+output "sanitized" {
+  # Returns "cpcoreprod"
+  value = provider::context::sanitize("cp_core.prod", "[^a-zA-Z0-9-]")
+}
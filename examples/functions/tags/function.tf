@@ -0,0 +1,10 @@
+data "context_config" "this" {}
+
+output "tags" {
+  value = provider::context::tags(data.context_config.this.encoded, {
+    tags_key_case = "lower"
+    values = {
+      name = "artifacts"
+    }
+  })
+}
@@ -0,0 +1,4 @@
+output "truncated" {
+  # Returns "foo-ba6094"
+  value = provider::context::truncate_with_hash("foo-bar-baz", 10)
+}
@@ -0,0 +1,2 @@
+# Keep the name of an existing bucket
+terraform import context_label.bucket cp-core-prod-logs
@@ -0,0 +1,41 @@
+terraform {
+  required_providers {
+    context = {
+      source = "registry.terraform.io/cloudposse/context"
+    }
+  }
+}
+
+provider "context" {
+  properties = {
+    namespace = { order = 1 }
+    tenant    = { order = 2 }
+    stage     = { order = 3 }
+    name      = { order = 4 }
+  }
+
+  values = {
+    "namespace" = "cp"
+    "tenant"    = "core"
+    "stage"     = "prod"
+  }
+}
+
+# The bucket name is rendered once and kept, even if the naming convention changes later
+resource "context_label" "bucket" {
+  # Fail the plan instead of renaming the bucket when it is replaced
+  prevent_change = true
+
+  values = {
+    "name" = "logs"
+  }
+
+  # Change the generation to rename the bucket on purpose
+  keepers = {
+    "generation" = "1"
+  }
+}
+
+output "bucket_name" {
+  value = context_label.bucket.rendered
+}
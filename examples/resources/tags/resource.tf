@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    context = {
+      source = "registry.terraform.io/cloudposse/context"
+    }
+  }
+}
+
+provider "context" {
+  properties = {
+    namespace = {}
+    stage     = {}
+    name      = {}
+  }
+
+  values = {
+    "namespace" = "cp"
+    "stage"     = "prod"
+  }
+}
+
+resource "context_tags" "database" {
+  values = {
+    "name" = "orders"
+  }
+}
+
+output "database_tags" {
+  value = context_tags.database.tags
+}
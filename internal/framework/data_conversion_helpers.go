@@ -2,11 +2,19 @@ package framework
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+var (
+	ErrUnknownValue     = errors.New("value is not known yet")
+	ErrUnsupportedValue = errors.New("unsupported value type")
+)
+
 // FromFrameworkMap converts a types.Map to a map[string]T.
 func FromFrameworkMap[T interface{}](ctx context.Context, m types.Map) (map[string]T, diag.Diagnostics) {
 	localValues := make(map[string]T, len(m.Elements()))
@@ -32,3 +40,118 @@ func FromFrameworkList[T interface{}](ctx context.Context, m types.List) ([]T, d
 	}
 	return localValues, nil
 }
+
+// FromFrameworkKnownStringMap converts the known elements of a types.Map of strings to a map[string]string and returns
+// the keys of the elements that are unknown. An unknown or null map has no known elements.
+func FromFrameworkKnownStringMap(m types.Map) (map[string]string, []string) {
+	known := map[string]string{}
+	unknown := []string{}
+	for k, v := range m.Elements() {
+		s, ok := v.(types.String)
+		switch {
+		case !ok:
+			continue
+		case s.IsUnknown():
+			unknown = append(unknown, k)
+		case !s.IsNull():
+			known[k] = s.ValueString()
+		}
+	}
+	return known, unknown
+}
+
+// IsFullyKnown returns false when the value, or any value nested in it, is unknown. Values that are only known during
+// apply, such as attributes of resources that are not created yet, are unknown at plan time.
+func IsFullyKnown(v attr.Value) bool {
+	if v == nil || v.IsNull() {
+		return true
+	}
+	if v.IsUnknown() {
+		return false
+	}
+
+	var elements []attr.Value
+	switch value := v.(type) {
+	case types.Dynamic:
+		return !value.IsUnderlyingValueUnknown() && IsFullyKnown(value.UnderlyingValue())
+	case types.Object:
+		for _, e := range value.Attributes() {
+			elements = append(elements, e)
+		}
+	case types.Map:
+		for _, e := range value.Elements() {
+			elements = append(elements, e)
+		}
+	case types.List:
+		elements = value.Elements()
+	case types.Set:
+		elements = value.Elements()
+	case types.Tuple:
+		elements = value.Elements()
+	}
+
+	for _, e := range elements {
+		if !IsFullyKnown(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// FromFrameworkValue converts any framework value to plain Go values: objects and maps become map[string]any, lists,
+// sets and tuples become []any, numbers become float64 and null values become nil. Unknown values are an error.
+func FromFrameworkValue(v attr.Value) (any, error) {
+	if v == nil || v.IsNull() {
+		return nil, nil
+	}
+	if v.IsUnknown() {
+		return nil, ErrUnknownValue
+	}
+
+	switch value := v.(type) {
+	case types.Dynamic:
+		return FromFrameworkValue(value.UnderlyingValue())
+	case types.String:
+		return value.ValueString(), nil
+	case types.Bool:
+		return value.ValueBool(), nil
+	case types.Number:
+		f, _ := value.ValueBigFloat().Float64()
+		return f, nil
+	case types.Object:
+		return fromFrameworkAttributes(value.Attributes())
+	case types.Map:
+		return fromFrameworkAttributes(value.Elements())
+	case types.List:
+		return fromFrameworkElements(value.Elements())
+	case types.Set:
+		return fromFrameworkElements(value.Elements())
+	case types.Tuple:
+		return fromFrameworkElements(value.Elements())
+	}
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedValue, v)
+}
+
+func fromFrameworkAttributes(attributes map[string]attr.Value) (map[string]any, error) {
+	result := make(map[string]any, len(attributes))
+	for k, v := range attributes {
+		converted, err := FromFrameworkValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+		result[k] = converted
+	}
+	return result, nil
+}
+
+func fromFrameworkElements(elements []attr.Value) ([]any, error) {
+	result := make([]any, 0, len(elements))
+	for i, v := range elements {
+		converted, err := FromFrameworkValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
@@ -0,0 +1,21 @@
+package model
+
+// ValueSourceChild marks a value set in the values of a context_child data source.
+const ValueSourceChild = "context_child"
+
+// NewChild returns a new context built from this one. The values are merged over the values of this context, the
+// property order replaces the order of this context when it is not empty and the options are applied last. Defaults
+// and derived values are filled in by the child, so values derived from values of the child follow them.
+func (c *ProviderConfig) NewChild(propertyOrder []string, values map[string]string, options ...func(*ProviderConfig)) (*ProviderConfig, error) {
+	sources := make(map[string]string, len(c.values)+len(values))
+	for k, v := range c.GetValueSources() {
+		sources[k] = v
+	}
+	for k := range values {
+		sources[k] = ValueSourceChild
+	}
+
+	childOptions := append(append(c.Options(), WithValueSources(sources)), options...)
+
+	return NewProviderConfig(c.properties, c.GetMergedPropertyOrder(propertyOrder), c.overrideValues(values), childOptions...)
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/cloudposse/terraform-provider-context/pkg/cases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderConfigNewChild(t *testing.T) {
+	parent := getEncodingProviderConfig(t)
+
+	child, err := parent.NewChild(nil, map[string]string{"name": "api", "stage": "dev"}, WithTagsKeyCase(cases.TitleCase))
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"namespace": "cp", "tenant": "core", "stage": "dev", "name": "api"}, child.GetValues())
+	assert.Equal(t, map[string]string{
+		"namespace": ValueSourceConfigFile,
+		"tenant":    ValueSourceProvider,
+		"stage":     ValueSourceChild,
+		"name":      ValueSourceChild,
+	}, child.GetValueSources())
+	assert.Equal(t, parent.GetPropertyOrder(), child.GetPropertyOrder())
+	assert.Equal(t, "_", child.GetDelimiter())
+	assert.Equal(t, cases.TitleCase.String(), child.GetTagsKeyCase())
+	assert.Equal(t, cases.UpperCase.String(), child.GetTagsValueCase())
+	assert.Equal(t, parent.GetLabelFormats(), child.GetLabelFormats())
+
+	// The parent is not changed by the child
+	assert.Equal(t, "prod", parent.GetValues()["stage"])
+	assert.Equal(t, cases.SnakeCase.String(), parent.GetTagsKeyCase())
+}
+
+func TestProviderConfigNewChildPropertyOrder(t *testing.T) {
+	parent := getEncodingProviderConfig(t)
+
+	child, err := parent.NewChild([]string{"tenant", "namespace"}, nil, WithDelimiter("__"))
+	assert.NoError(t, err)
+
+	label, errs := child.GetDelimitedLabel(nil, nil, nil, nil, nil, 0, false)
+	assert.Empty(t, errs)
+	assert.Equal(t, "core__cp", label)
+}
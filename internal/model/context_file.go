@@ -0,0 +1,420 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/cloudposse/terraform-provider-context/pkg/cases"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrInvalidContextFile = errors.New("invalid context file")
+	ErrUnknownKey         = errors.New("unknown key")
+	ErrNegativeValue      = errors.New("value must be at least 0")
+)
+
+// ContextFile is a provider context loaded from a YAML or JSON document. It holds the same settings as the provider
+// configuration, so a naming convention can be shared between root modules.
+type ContextFile struct {
+	Delimiter         *string
+	Enabled           *bool
+	LabelFormats      map[string]LabelFormat
+	Lookups           map[string]Lookup
+	Properties        []Property
+	PropertyOrder     []string
+	ReplaceCharsRegex *string
+	TagsKeyCase       *cases.Case
+	TagsValueCase     *cases.Case
+	Validations       []ValidationRule
+	Values            map[string]string
+}
+
+// ContextFileError describes a problem with an entry in a context file.
+type ContextFileError struct {
+	File    string
+	KeyPath string
+	Line    int
+	Err     error
+}
+
+func (e *ContextFileError) Error() string {
+	location := e.File
+	if e.KeyPath != "" {
+		location = fmt.Sprintf("%s: %s", location, e.KeyPath)
+	}
+	if e.Line > 0 {
+		location = fmt.Sprintf("%s (line %d)", location, e.Line)
+	}
+	return fmt.Sprintf("%s: %s", location, e.Err)
+}
+
+func (e *ContextFileError) Unwrap() error {
+	return e.Err
+}
+
+// fieldDecoder decodes the value of a single key in a context file.
+type fieldDecoder func(node *yaml.Node, keyPath string) error
+
+// LoadContextFile reads and parses a YAML or JSON context file.
+func LoadContextFile(path string) (*ContextFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &ContextFileError{File: path, Err: err}
+	}
+	return ParseContextFile(path, data)
+}
+
+// ParseContextFile parses a YAML or JSON context document. The file name is only used in error messages.
+func ParseContextFile(file string, data []byte) (*ContextFile, error) {
+	cf := &ContextFile{Values: map[string]string{}}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, &ContextFileError{File: file, Err: fmt.Errorf("%w: %w", ErrInvalidContextFile, err)}
+	}
+	if len(doc.Content) == 0 {
+		return cf, nil
+	}
+
+	decoders := map[string]fieldDecoder{
+		"delimiter":           decodeInto(&cf.Delimiter),
+		"enabled":             decodeInto(&cf.Enabled),
+		"label_formats":       cf.decodeLabelFormats,
+		"lookups":             cf.decodeLookups,
+		"properties":          cf.decodeProperties,
+		"property_order":      decodeInto(&cf.PropertyOrder),
+		"replace_chars_regex": decodeInto(&cf.ReplaceCharsRegex),
+		"tags_key_case":       decodeCase(&cf.TagsKeyCase),
+		"tags_value_case":     decodeCase(&cf.TagsValueCase),
+		"validations":         cf.decodeValidations,
+		"values":              decodeInto(&cf.Values),
+	}
+	if err := decodeMapping(doc.Content[0], "", decoders); err != nil {
+		return nil, withFile(err, file)
+	}
+
+	return cf, nil
+}
+
+// Options returns the provider config options set in the file.
+func (f *ContextFile) Options() []func(*ProviderConfig) {
+	options := []func(*ProviderConfig){}
+	if f.Enabled != nil {
+		options = append(options, WithEnabled(*f.Enabled))
+	}
+	if f.Delimiter != nil {
+		options = append(options, WithDelimiter(*f.Delimiter))
+	}
+	if f.ReplaceCharsRegex != nil {
+		options = append(options, WithReplaceCharsRegex(*f.ReplaceCharsRegex))
+	}
+	if f.TagsKeyCase != nil {
+		options = append(options, WithTagsKeyCase(*f.TagsKeyCase))
+	}
+	if f.TagsValueCase != nil {
+		options = append(options, WithTagsValueCase(*f.TagsValueCase))
+	}
+	if len(f.LabelFormats) > 0 {
+		options = append(options, WithLabelFormats(f.LabelFormats))
+	}
+	if len(f.Lookups) > 0 {
+		options = append(options, WithLookups(f.Lookups))
+	}
+	if len(f.Validations) > 0 {
+		options = append(options, WithValidationRules(f.Validations))
+	}
+	return options
+}
+
+func (f *ContextFile) decodeProperties(node *yaml.Node, keyPath string) error {
+	if node.Kind != yaml.MappingNode {
+		return &ContextFileError{KeyPath: keyPath, Line: node.Line, Err: fmt.Errorf("%w: expected a map of properties", ErrInvalidContextFile)}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name := node.Content[i].Value
+		property, err := decodeProperty(name, node.Content[i+1], joinKeyPath(keyPath, name))
+		if err != nil {
+			return err
+		}
+		f.Properties = append(f.Properties, *property)
+	}
+
+	// Keep the properties in a stable order, like the provider does for inline properties
+	sort.SliceStable(f.Properties, func(i, j int) bool {
+		return f.Properties[i].Name < f.Properties[j].Name
+	})
+	return nil
+}
+
+func (f *ContextFile) decodeLabelFormats(node *yaml.Node, keyPath string) error {
+	if node.Kind != yaml.MappingNode {
+		return &ContextFileError{KeyPath: keyPath, Line: node.Line, Err: fmt.Errorf("%w: expected a map of label formats", ErrInvalidContextFile)}
+	}
+
+	f.LabelFormats = make(map[string]LabelFormat, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name := node.Content[i].Value
+		formatPath := joinKeyPath(keyPath, name)
+
+		var format LabelFormat
+		decoders := map[string]fieldDecoder{
+			"delimiter":  decodeInto(&format.Delimiter),
+			"max_length": decodeLength(&format.MaxLength),
+			"properties": decodeInto(&format.Properties),
+			"template":   decodeInto(&format.Template),
+			"truncate":   decodeInto(&format.Truncate),
+		}
+		if err := decodeMapping(node.Content[i+1], formatPath, decoders); err != nil {
+			return err
+		}
+		if err := format.Validate(); err != nil {
+			return &ContextFileError{KeyPath: formatPath, Line: node.Content[i].Line, Err: err}
+		}
+		f.LabelFormats[name] = format
+	}
+	return nil
+}
+
+func (f *ContextFile) decodeLookups(node *yaml.Node, keyPath string) error {
+	if node.Kind != yaml.MappingNode {
+		return &ContextFileError{KeyPath: keyPath, Line: node.Line, Err: fmt.Errorf("%w: expected a map of lookups", ErrInvalidContextFile)}
+	}
+
+	f.Lookups = make(map[string]Lookup, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name := node.Content[i].Value
+
+		var lookup Lookup
+		decoders := map[string]fieldDecoder{
+			"strict": decodeInto(&lookup.Strict),
+			"values": decodeInto(&lookup.Values),
+		}
+		if err := decodeMapping(node.Content[i+1], joinKeyPath(keyPath, name), decoders); err != nil {
+			return err
+		}
+		f.Lookups[name] = lookup
+	}
+	return nil
+}
+
+// decodedProperty holds the settings of a property in a context file. Settings that are not set are nil.
+type decodedProperty struct {
+	allowedValueDescriptions map[string]string
+	allowedValues            []string
+	defaultValue             *string
+	deriveFrom               *string
+	includeInTags, required  *bool
+	labelLookup, tagLookup   *string
+	maxLength, minLength     *int
+	order                    *int
+	requiredWhen             map[string][]string
+	severity                 map[string]string
+	tagsKeyCase              *cases.Case
+	tagsValueCase            *cases.Case
+	validationRegex          *string
+}
+
+func (f *ContextFile) decodeValidations(node *yaml.Node, keyPath string) error {
+	if node.Kind != yaml.SequenceNode {
+		return &ContextFileError{KeyPath: keyPath, Line: node.Line, Err: fmt.Errorf("%w: expected a list of validation rules", ErrInvalidContextFile)}
+	}
+
+	for i, item := range node.Content {
+		rulePath := fmt.Sprintf("%s[%d]", keyPath, i)
+
+		var rule ValidationRule
+		decoders := map[string]fieldDecoder{
+			"condition":     decodeInto(&rule.Condition),
+			"error_message": decodeInto(&rule.ErrorMessage),
+			"name":          decodeInto(&rule.Name),
+		}
+		if err := decodeMapping(item, rulePath, decoders); err != nil {
+			return err
+		}
+		if err := rule.Validate(); err != nil {
+			return &ContextFileError{KeyPath: rulePath, Line: item.Line, Err: err}
+		}
+		f.Validations = append(f.Validations, rule)
+	}
+	return nil
+}
+
+func decodeProperty(name string, node *yaml.Node, keyPath string) (*Property, error) {
+	var d decodedProperty
+	decoders := map[string]fieldDecoder{
+		"allowed_value_descriptions": decodeInto(&d.allowedValueDescriptions),
+		"allowed_values":             decodeInto(&d.allowedValues),
+		"default":                    decodeInto(&d.defaultValue),
+		"derive_from":                decodeInto(&d.deriveFrom),
+		"include_in_tags":            decodeInto(&d.includeInTags),
+		"label_lookup":               decodeInto(&d.labelLookup),
+		"max_length":                 decodeLength(&d.maxLength),
+		"min_length":                 decodeLength(&d.minLength),
+		"order":                      decodeInto(&d.order),
+		"required":                   decodeInto(&d.required),
+		"required_when":              decodeInto(&d.requiredWhen),
+		"severity":                   decodeInto(&d.severity),
+		"tag_lookup":                 decodeInto(&d.tagLookup),
+		"tags_key_case":              decodeCase(&d.tagsKeyCase),
+		"tags_value_case":            decodeCase(&d.tagsValueCase),
+		"validation_regex":           decodeInto(&d.validationRegex),
+	}
+	// An empty property, e.g. "name: {}" or "name:", uses the defaults
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!null" {
+		if err := decodeMapping(node, keyPath, decoders); err != nil {
+			return nil, err
+		}
+	}
+
+	options := d.options()
+	if d.allowedValues != nil || d.allowedValueDescriptions != nil {
+		av, err := NewAllowedValues(d.allowedValues, d.allowedValueDescriptions)
+		if err != nil {
+			return nil, &ContextFileError{KeyPath: joinKeyPath(keyPath, "allowed_value_descriptions"), Line: node.Line, Err: err}
+		}
+		options = append(options, WithAllowedValues(av))
+	}
+	if d.severity != nil {
+		severities, err := NewSeverities(d.severity)
+		if err != nil {
+			return nil, &ContextFileError{KeyPath: joinKeyPath(keyPath, "severity"), Line: node.Line, Err: err}
+		}
+		options = append(options, WithSeverities(severities))
+	}
+
+	return NewProperty(name, options...), nil
+}
+
+// options returns the property options for the settings that are set.
+func (d *decodedProperty) options() []PropertyOption {
+	options := []PropertyOption{}
+	if d.required != nil && *d.required {
+		options = append(options, WithRequired())
+	}
+	if d.requiredWhen != nil {
+		options = append(options, WithRequiredWhen(d.requiredWhen))
+	}
+	if d.includeInTags != nil && !*d.includeInTags {
+		options = append(options, WithExcludeFromTags())
+	}
+	if d.minLength != nil {
+		options = append(options, WithMinLength(*d.minLength))
+	}
+	if d.maxLength != nil {
+		options = append(options, WithMaxLength(*d.maxLength))
+	}
+	if d.order != nil {
+		options = append(options, WithOrder(*d.order))
+	}
+	if d.validationRegex != nil {
+		options = append(options, WithValidationRegex(*d.validationRegex))
+	}
+	if d.defaultValue != nil {
+		options = append(options, WithDefault(*d.defaultValue))
+	}
+	if d.deriveFrom != nil {
+		options = append(options, WithDeriveFrom(*d.deriveFrom))
+	}
+	if d.labelLookup != nil {
+		options = append(options, WithLabelLookup(*d.labelLookup))
+	}
+	if d.tagLookup != nil {
+		options = append(options, WithTagLookup(*d.tagLookup))
+	}
+	if d.tagsKeyCase != nil {
+		options = append(options, WithPropertyTagsKeyCase(*d.tagsKeyCase))
+	}
+	if d.tagsValueCase != nil {
+		options = append(options, WithPropertyTagsValueCase(*d.tagsValueCase))
+	}
+	return options
+}
+
+// decodeMapping decodes every key of a mapping node with the matching decoder. Keys without a decoder are rejected.
+func decodeMapping(node *yaml.Node, keyPath string, decoders map[string]fieldDecoder) error {
+	if node.Kind != yaml.MappingNode {
+		return &ContextFileError{KeyPath: keyPath, Line: node.Line, Err: fmt.Errorf("%w: expected a map", ErrInvalidContextFile)}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		childPath := joinKeyPath(keyPath, key)
+		decoder, ok := decoders[key]
+		if !ok {
+			return &ContextFileError{KeyPath: childPath, Line: node.Content[i].Line, Err: fmt.Errorf("%w, valid keys are: %s", ErrUnknownKey, strings.Join(sortedKeys(decoders), ", "))}
+		}
+		if err := decoder(node.Content[i+1], childPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeInto returns a decoder that decodes the value into the target.
+func decodeInto[T any](target *T) fieldDecoder {
+	return func(node *yaml.Node, keyPath string) error {
+		if err := node.Decode(target); err != nil {
+			return &ContextFileError{KeyPath: keyPath, Line: node.Line, Err: fmt.Errorf("%w: %w", ErrInvalidContextFile, err)}
+		}
+		return nil
+	}
+}
+
+// decodeLength returns a decoder for a length, which must not be negative.
+func decodeLength(target **int) fieldDecoder {
+	return func(node *yaml.Node, keyPath string) error {
+		if err := decodeInto(target)(node, keyPath); err != nil {
+			return err
+		}
+		if *target != nil && **target < 0 {
+			return &ContextFileError{KeyPath: keyPath, Line: node.Line, Err: ErrNegativeValue}
+		}
+		return nil
+	}
+}
+
+// decodeCase returns a decoder for a case name.
+func decodeCase(target **cases.Case) fieldDecoder {
+	return func(node *yaml.Node, keyPath string) error {
+		var name string
+		if err := decodeInto(&name)(node, keyPath); err != nil {
+			return err
+		}
+		c, err := cases.FromString(name)
+		if err != nil || c == cases.Unknown {
+			return &ContextFileError{KeyPath: keyPath, Line: node.Line, Err: fmt.Errorf("%w: %q", cases.ErrUnknownCase, name)}
+		}
+		*target = &c
+		return nil
+	}
+}
+
+func joinKeyPath(keyPath string, key string) string {
+	if keyPath == "" {
+		return key
+	}
+	return keyPath + "." + key
+}
+
+func sortedKeys(decoders map[string]fieldDecoder) []string {
+	keys := make([]string, 0, len(decoders))
+	for k := range decoders {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// withFile sets the file name on a context file error.
+func withFile(err error, file string) error {
+	var cfErr *ContextFileError
+	if errors.As(err, &cfErr) {
+		cfErr.File = file
+	}
+	return err
+}
@@ -0,0 +1,267 @@
+package model
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudposse/terraform-provider-context/pkg/cases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseContextFileYAML(t *testing.T) {
+	data := `
+delimiter: "_"
+enabled: true
+tags_key_case: lower
+properties:
+  namespace:
+    required: true
+    max_length: 10
+    order: 1
+  name:
+    include_in_tags: false
+    tags_value_case: upper
+  stage:
+property_order: [namespace, stage, name]
+values:
+  namespace: cp
+  name: example
+`
+	cf, err := ParseContextFile("context.yaml", []byte(data))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "_", *cf.Delimiter)
+	assert.Equal(t, true, *cf.Enabled)
+	assert.Equal(t, cases.LowerCase, *cf.TagsKeyCase)
+	assert.Nil(t, cf.TagsValueCase)
+	assert.Equal(t, []string{"namespace", "stage", "name"}, cf.PropertyOrder)
+	assert.Equal(t, map[string]string{"namespace": "cp", "name": "example"}, cf.Values)
+
+	assert.Equal(t, 3, len(cf.Properties))
+	assert.Equal(t, "name", cf.Properties[0].Name)
+	assert.Equal(t, false, cf.Properties[0].IncludeInTags)
+	assert.Equal(t, cases.UpperCase, *cf.Properties[0].TagsValueCase)
+	assert.Equal(t, "namespace", cf.Properties[1].Name)
+	assert.Equal(t, true, cf.Properties[1].Required)
+	assert.Equal(t, 10, cf.Properties[1].MaxLength)
+	assert.Equal(t, 1, cf.Properties[1].Order)
+	assert.Equal(t, *NewProperty("stage"), cf.Properties[2])
+}
+
+func TestParseContextFileJSON(t *testing.T) {
+	data := `{"delimiter": "~", "properties": {"namespace": {"required": true}}, "values": {"namespace": "cp"}}`
+
+	cf, err := ParseContextFile("context.json", []byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, "~", *cf.Delimiter)
+	assert.Equal(t, []Property{*NewProperty("namespace", WithRequired())}, cf.Properties)
+	assert.Equal(t, map[string]string{"namespace": "cp"}, cf.Values)
+}
+
+func TestParseContextFileLabelFormats(t *testing.T) {
+	data := `
+label_formats:
+  stack:
+    template: "{{.tenant}}-{{.stage}}"
+  account:
+    properties: [tenant, stage]
+    delimiter: "_"
+    max_length: 20
+    truncate: false
+`
+	cf, err := ParseContextFile("context.yaml", []byte(data))
+	assert.NoError(t, err)
+
+	delimiter, maxLength, template, truncate := "_", 20, "{{.tenant}}-{{.stage}}", false
+	assert.Equal(t, map[string]LabelFormat{
+		"stack":   {Template: &template},
+		"account": {Delimiter: &delimiter, MaxLength: &maxLength, Properties: []string{"tenant", "stage"}, Truncate: &truncate},
+	}, cf.LabelFormats)
+}
+
+func TestParseContextFileAllowedValues(t *testing.T) {
+	data := `
+properties:
+  stage:
+    allowed_values: [dev, staging, prod]
+    allowed_value_descriptions:
+      prod: Production
+`
+	cf, err := ParseContextFile("context.yaml", []byte(data))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []AllowedValue{{Value: "dev"}, {Value: "staging"}, {Value: "prod", Description: "Production"}}, cf.Properties[0].AllowedValues)
+}
+
+func TestParseContextFileDerivedValues(t *testing.T) {
+	data := `
+properties:
+  stage:
+    default: dev
+  id:
+    derive_from: "{{.namespace}}-{{.stage}}"
+`
+	cf, err := ParseContextFile("context.yaml", []byte(data))
+	assert.NoError(t, err)
+
+	properties := map[string]Property{}
+	for _, p := range cf.Properties {
+		properties[p.Name] = p
+	}
+	assert.Equal(t, "dev", properties["stage"].Default)
+	assert.Equal(t, "{{.namespace}}-{{.stage}}", properties["id"].DeriveFrom)
+}
+
+func TestParseContextFileRequiredWhen(t *testing.T) {
+	data := `
+properties:
+  tenant:
+    required_when:
+      namespace: [acme, globex]
+`
+	cf, err := ParseContextFile("context.yaml", []byte(data))
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string][]string{"namespace": {"acme", "globex"}}, cf.Properties[0].RequiredWhen)
+}
+
+func TestParseContextFileSeverity(t *testing.T) {
+	data := `
+properties:
+  stage:
+    validation_regex: "^[a-z]+$"
+    severity:
+      validation_regex: warning
+`
+	cf, err := ParseContextFile("context.yaml", []byte(data))
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]Severity{CheckValidationRegex: SeverityWarning}, cf.Properties[0].Severities)
+}
+
+func TestParseContextFileLookups(t *testing.T) {
+	data := `
+lookups:
+  regions:
+    strict: true
+    values:
+      us-west-2: uw2
+properties:
+  region:
+    label_lookup: regions
+`
+	cf, err := ParseContextFile("context.yaml", []byte(data))
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]Lookup{"regions": {Values: map[string]string{"us-west-2": "uw2"}, Strict: true}}, cf.Lookups)
+	assert.Equal(t, "regions", cf.Properties[0].LabelLookup)
+	assert.Empty(t, cf.Properties[0].TagLookup)
+	assert.Len(t, cf.Options(), 1)
+}
+
+func TestParseContextFileValidations(t *testing.T) {
+	data := `
+validations:
+  - name: name_length
+    condition: "{{ le (len .name) 10 }}"
+    error_message: "{{.name}} is too long"
+`
+	cf, err := ParseContextFile("context.yaml", []byte(data))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []ValidationRule{{Name: "name_length", Condition: "{{ le (len .name) 10 }}", ErrorMessage: "{{.name}} is too long"}}, cf.Validations)
+	assert.Len(t, cf.Options(), 1)
+}
+
+func TestParseContextFileErrors(t *testing.T) {
+	testCases := map[string]struct {
+		data     string
+		expected string
+		err      error
+	}{
+		"unknown top level key": {
+			data:     "delimitor: _\n",
+			expected: "context.yaml: delimitor (line 1): unknown key",
+			err:      ErrUnknownKey,
+		},
+		"unknown property key": {
+			data:     "properties:\n  namespace:\n    requried: true\n",
+			expected: "context.yaml: properties.namespace.requried (line 3): unknown key",
+			err:      ErrUnknownKey,
+		},
+		"invalid case": {
+			data:     "properties:\n  namespace:\n    tags_key_case: kebab\n",
+			expected: `context.yaml: properties.namespace.tags_key_case (line 3): unknown case: "kebab"`,
+			err:      cases.ErrUnknownCase,
+		},
+		"negative length": {
+			data:     "properties:\n  namespace:\n    min_length: -1\n",
+			expected: "context.yaml: properties.namespace.min_length (line 3): value must be at least 0",
+			err:      ErrNegativeValue,
+		},
+		"description of a value that is not allowed": {
+			data:     "properties:\n  stage:\n    allowed_values: [dev, prod]\n    allowed_value_descriptions:\n      qa: Quality assurance\n",
+			expected: "context.yaml: properties.stage.allowed_value_descriptions (line 3): description is for a value that is not allowed: qa",
+			err:      ErrUnknownAllowedValue,
+		},
+		"unknown severity": {
+			data:     "properties:\n  stage:\n    severity:\n      required: info\n",
+			expected: `context.yaml: properties.stage.severity (line 3): check required: unknown severity: "info", valid severities are: error, warning`,
+			err:      ErrUnknownSeverity,
+		},
+		"severity of an unknown check": {
+			data:     "properties:\n  stage:\n    severity:\n      pattern: warning\n",
+			expected: `context.yaml: properties.stage.severity (line 3): unknown check: "pattern"`,
+			err:      ErrUnknownCheck,
+		},
+		"unknown lookup key": {
+			data:     "lookups:\n  regions:\n    value:\n      us-west-2: uw2\n",
+			expected: "context.yaml: lookups.regions.value (line 3): unknown key",
+			err:      ErrUnknownKey,
+		},
+		"validation rule without a condition": {
+			data:     "validations:\n  - name: rule\n",
+			expected: "context.yaml: validations[0] (line 2): invalid validation rule: condition of rule rule must not be empty",
+			err:      ErrInvalidValidationRule,
+		},
+		"conflicting label format": {
+			data:     "label_formats:\n  stack:\n    template: \"{{.stage}}\"\n    delimiter: \"-\"\n",
+			expected: "context.yaml: label_formats.stack (line 2): invalid label format: template conflicts with delimiter and properties",
+			err:      ErrInvalidLabelFormat,
+		},
+		"wrong type": {
+			data:     "enabled: maybe\n",
+			expected: "context.yaml: enabled (line 1): invalid context file",
+			err:      ErrInvalidContextFile,
+		},
+		"invalid syntax": {
+			data:     "values: [\n",
+			expected: "context.yaml: invalid context file",
+			err:      ErrInvalidContextFile,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseContextFile("context.yaml", []byte(tc.data))
+			assert.Error(t, err)
+			assert.True(t, errors.Is(err, tc.err))
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestLoadContextFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "context.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte("delimiter: \"~\"\n"), 0o600))
+
+	cf, err := LoadContextFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "~", *cf.Delimiter)
+
+	_, err = LoadContextFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "missing.yaml")
+}
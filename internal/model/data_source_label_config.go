@@ -1,10 +1,19 @@
 package model
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"github.com/cloudposse/terraform-provider-context/internal/framework"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 // DataSourceLabelConfig describes the label data source data model.
 type DataSourceLabelConfig struct {
+	Context           types.String `tfsdk:"context"`
 	Delimiter         types.String `tfsdk:"delimiter"`
+	Enabled           types.Bool   `tfsdk:"enabled"`
+	Explain           types.Bool   `tfsdk:"explain"`
+	Explanation       types.Object `tfsdk:"explanation"`
+	Format            types.String `tfsdk:"format"`
 	Id                types.String `tfsdk:"id"`
 	MaxLength         types.Int64  `tfsdk:"max_length"`
 	Properties        types.List   `tfsdk:"properties"`
@@ -14,3 +23,14 @@ type DataSourceLabelConfig struct {
 	Truncate          types.Bool   `tfsdk:"truncate"`
 	Values            types.Map    `tfsdk:"values"`
 }
+
+// IsFullyKnown returns false when any of the settings used to render the label is unknown, which happens at plan time
+// when they are computed from resources that are not created yet.
+func (c *DataSourceLabelConfig) IsFullyKnown() bool {
+	for _, v := range []attr.Value{c.Context, c.Delimiter, c.Enabled, c.Format, c.MaxLength, c.Properties, c.ReplaceCharsRegex, c.Template, c.Truncate, c.Values} {
+		if !framework.IsFullyKnown(v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,207 @@
+package model
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+var (
+	ErrInvalidDeriveFrom = errors.New("invalid derive_from")
+	ErrDerivedValueCycle = errors.New("derived values depend on each other")
+	ErrDeriveFailed      = errors.New("failed to derive value")
+)
+
+const (
+	// ValueSourceDefault marks a value filled in from the default of its property.
+	ValueSourceDefault = "default"
+	// ValueSourceDerived marks a value derived from other values with the derive_from template of its property.
+	ValueSourceDerived = "derived"
+)
+
+// derivation is a property whose value is derived from other values with a template.
+type derivation struct {
+	name      string
+	template  *template.Template
+	dependsOn []string
+}
+
+// newDerivation parses the derive_from template of a property. The template must reference the values by name, so the
+// values it depends on are known before it is executed, and every lookup it names must exist.
+func (c *ProviderConfig) newDerivation(p *Property) (derivation, error) {
+	if p.Default != "" {
+		return derivation{}, fmt.Errorf("%w: property %s cannot have both a default and derive_from", ErrInvalidDeriveFrom, p.Name)
+	}
+	// A value that is not set renders as an empty string rather than "<no value>"
+	tmpl, err := template.New(p.Name).Funcs(template.FuncMap{"lookup": c.lookupValue}).Option("missingkey=zero").Parse(p.DeriveFrom)
+	if err != nil {
+		return derivation{}, fmt.Errorf("%w: property %s: %w", ErrInvalidDeriveFrom, p.Name, err)
+	}
+
+	fields := collectTemplateFields(tmpl)
+	if fields.dynamic {
+		return derivation{}, fmt.Errorf("%w: property %s must reference values by name, e.g. {{.region}} or {{index . \"region\"}}", ErrInvalidDeriveFrom, p.Name)
+	}
+	for _, name := range fields.lookups {
+		if _, err := c.GetLookup(name); err != nil {
+			return derivation{}, fmt.Errorf("%w: property %s: %w", ErrInvalidDeriveFrom, p.Name, err)
+		}
+	}
+	return derivation{name: p.Name, template: tmpl, dependsOn: fields.names}, nil
+}
+
+// lookupValue is the lookup function of derive_from templates, e.g. {{lookup "aws_region_short" .region}}. It returns
+// the value from the lookup with the given name, or the value itself if it is not in the lookup, unless the lookup is
+// strict. An empty value is returned as it is.
+func (c *ProviderConfig) lookupValue(name string, value string) (string, error) {
+	lookup, err := c.GetLookup(name)
+	if err != nil {
+		return "", err
+	}
+	if replacement, ok := lookup.Values[value]; ok {
+		return replacement, nil
+	}
+	if lookup.Strict && value != "" {
+		return "", fmt.Errorf("%w: %s in lookup %s", ErrValueNotInLookup, value, name)
+	}
+	return value, nil
+}
+
+// newDerivations parses the derive_from templates of the properties and returns them in dependency order, so every
+// derived value is rendered after the derived values it depends on. Derived values that depend on each other, directly
+// or through other derived values, are rejected.
+func (c *ProviderConfig) newDerivations() ([]derivation, error) {
+	byName := map[string]derivation{}
+	for i := range c.properties {
+		if c.properties[i].DeriveFrom == "" {
+			continue
+		}
+		d, err := c.newDerivation(&c.properties[i])
+		if err != nil {
+			return nil, err
+		}
+		byName[d.name] = d
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ordered := make([]derivation, 0, len(names))
+	visited := map[string]bool{}
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		d, ok := byName[name]
+		if !ok || visited[name] {
+			return nil
+		}
+		if i := slices.Index(path, name); i >= 0 {
+			return fmt.Errorf("%w: %s", ErrDerivedValueCycle, strings.Join(append(path[i:], name), " -> "))
+		}
+		path = append(slices.Clone(path), name)
+		for _, dependency := range d.dependsOn {
+			if err := visit(dependency, path); err != nil {
+				return err
+			}
+		}
+		visited[name] = true
+		ordered = append(ordered, d)
+		return nil
+	}
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// overrideValues merges the values passed in to the function over the values from the context, without filling in
+// defaults or derived values.
+func (c *ProviderConfig) overrideValues(values map[string]string) map[string]string {
+	mergedValues := make(map[string]string, len(c.values)+len(values))
+	for key, value := range c.values {
+		mergedValues[key] = value
+	}
+
+	for key, value := range values {
+		mergedValues[key] = value
+	}
+
+	return mergedValues
+}
+
+// ResolveValues merges the values passed in to the function over the values from the context, then fills in the
+// default of every property without a value and derives the value of every property with derive_from and without a
+// value, in dependency order, so derived values can use defaults and other derived values. A derived value that fails
+// to render is left empty and returned as an error.
+func (c *ProviderConfig) ResolveValues(values map[string]string) (map[string]string, []error) {
+	resolved, _, errs := c.resolveValues(values, nil)
+	return resolved, errs
+}
+
+// resolveValues resolves the values like ResolveValues, skipping the values that are not known yet. Values derived from
+// unknown values are unknown too, so they are returned along with the given unknown values.
+func (c *ProviderConfig) resolveValues(values map[string]string, unknown []string) (map[string]string, []string, []error) {
+	resolved := c.overrideValues(values)
+	for _, p := range c.properties {
+		if p.Default != "" && resolved[p.Name] == "" {
+			resolved[p.Name] = p.Default
+		}
+	}
+
+	unknown = slices.Clone(unknown)
+	errs := []error{}
+	for _, d := range c.derivations {
+		if resolved[d.name] != "" || slices.Contains(unknown, d.name) {
+			continue
+		}
+		if slices.ContainsFunc(d.dependsOn, func(name string) bool { return slices.Contains(unknown, name) }) {
+			unknown = append(unknown, d.name)
+			continue
+		}
+
+		var result bytes.Buffer
+		if err := d.template.Execute(&result, resolved); err != nil {
+			errs = append(errs, &PropertyError{Property: d.name, Err: fmt.Errorf("%w for property %s: %w", ErrDeriveFailed, d.name, err)})
+			continue
+		}
+		resolved[d.name] = result.String()
+	}
+
+	return resolved, unknown, errs
+}
+
+// getFilledValueSource returns the source of a value that is filled in because the property has no value of its own,
+// which is the case when the value that was set is empty.
+func (c *ProviderConfig) getFilledValueSource(name string) (string, bool) {
+	for _, p := range c.properties {
+		if p.Name != name {
+			continue
+		}
+		switch {
+		case p.DeriveFrom != "":
+			return ValueSourceDerived, true
+		case p.Default != "":
+			return ValueSourceDefault, true
+		}
+	}
+	return "", false
+}
+
+// GetMergedValueSources returns the source of each value returned by GetMergedValues for the same values.
+func (c *ProviderConfig) GetMergedValueSources(values map[string]string) map[string]string {
+	resolved := c.GetMergedValues(values)
+	sources := make(map[string]string, len(resolved))
+	for name := range resolved {
+		sources[name] = c.getValueSource(name, values)
+	}
+	return sources
+}
@@ -0,0 +1,233 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getDerivedProviderConfig(t *testing.T, values map[string]string) *ProviderConfig {
+	t.Helper()
+	properties := []Property{
+		*NewProperty("namespace", WithRequired()),
+		*NewProperty("stage", WithDefault("dev"), WithAllowedValues([]AllowedValue{{Value: "dev"}, {Value: "prod"}})),
+		*NewProperty("name"),
+		*NewProperty("id", WithDeriveFrom("{{.prefix}}-{{.name}}")),
+		*NewProperty("prefix", WithDeriveFrom("{{.namespace}}-{{.stage}}")),
+	}
+	c, err := NewProviderConfig(properties, []string{"namespace", "stage", "name"}, values)
+	assert.NoError(t, err)
+	return c
+}
+
+func TestResolveValuesFillsDefaults(t *testing.T) {
+	c := getDerivedProviderConfig(t, map[string]string{"namespace": "cp"})
+
+	resolved, errs := c.ResolveValues(nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, "dev", resolved["stage"])
+
+	// A value that is set replaces the default, unless it is empty
+	resolved, errs = c.ResolveValues(map[string]string{"stage": "prod"})
+	assert.Empty(t, errs)
+	assert.Equal(t, "prod", resolved["stage"])
+	resolved, errs = c.ResolveValues(map[string]string{"stage": ""})
+	assert.Empty(t, errs)
+	assert.Equal(t, "dev", resolved["stage"])
+}
+
+func TestResolveValuesDerivesInDependencyOrder(t *testing.T) {
+	c := getDerivedProviderConfig(t, map[string]string{"namespace": "cp"})
+
+	resolved, errs := c.ResolveValues(map[string]string{"name": "app"})
+	assert.Empty(t, errs)
+	assert.Equal(t, "cp-dev", resolved["prefix"])
+	assert.Equal(t, "cp-dev-app", resolved["id"])
+
+	// A value that is set is not derived
+	resolved, errs = c.ResolveValues(map[string]string{"name": "app", "prefix": "x"})
+	assert.Empty(t, errs)
+	assert.Equal(t, "x", resolved["prefix"])
+	assert.Equal(t, "x-app", resolved["id"])
+}
+
+func TestResolveValuesDoesNotModifyContext(t *testing.T) {
+	c := getDerivedProviderConfig(t, map[string]string{"namespace": "cp"})
+
+	_, errs := c.ResolveValues(map[string]string{"name": "app"})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]string{"namespace": "cp"}, c.GetValues())
+}
+
+func TestResolveValuesDeriveFailed(t *testing.T) {
+	properties := []Property{*NewProperty("id", WithDeriveFrom(`{{index .tags "a"}}`))}
+	c, err := NewProviderConfig(properties, []string{}, map[string]string{})
+	assert.NoError(t, err)
+
+	resolved, errs := c.ResolveValues(nil)
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrDeriveFailed)
+	var propertyErr *PropertyError
+	assert.ErrorAs(t, errs[0], &propertyErr)
+	assert.Equal(t, "id", propertyErr.Property)
+	assert.Empty(t, resolved["id"])
+}
+
+func TestResolveValuesUnknown(t *testing.T) {
+	c := getDerivedProviderConfig(t, map[string]string{"namespace": "cp"})
+
+	resolved, unknown, errs := c.resolveValues(map[string]string{"name": "app"}, []string{"stage"})
+	assert.Empty(t, errs)
+	assert.ElementsMatch(t, []string{"stage", "prefix", "id"}, unknown)
+	assert.NotContains(t, resolved, "prefix")
+	assert.NotContains(t, resolved, "id")
+}
+
+func TestNewDerivationsCycle(t *testing.T) {
+	properties := []Property{
+		*NewProperty("a", WithDeriveFrom("{{.b}}")),
+		*NewProperty("b", WithDeriveFrom("{{.c}}")),
+		*NewProperty("c", WithDeriveFrom("{{.a}}")),
+	}
+	_, err := NewProviderConfig(properties, []string{}, map[string]string{})
+	assert.ErrorIs(t, err, ErrDerivedValueCycle)
+	assert.ErrorContains(t, err, "a -> b -> c -> a")
+
+	properties = []Property{*NewProperty("a", WithDeriveFrom("{{.a}}-x"))}
+	_, err = NewProviderConfig(properties, []string{}, map[string]string{})
+	assert.ErrorIs(t, err, ErrDerivedValueCycle)
+	assert.ErrorContains(t, err, "a -> a")
+}
+
+func TestNewDerivationsInvalid(t *testing.T) {
+	properties := []Property{*NewProperty("a", WithDeriveFrom("{{.b"))}
+	_, err := NewProviderConfig(properties, []string{}, map[string]string{})
+	assert.ErrorIs(t, err, ErrInvalidDeriveFrom)
+
+	properties = []Property{*NewProperty("a", WithDefault("x"), WithDeriveFrom("{{.b}}"))}
+	_, err = NewProviderConfig(properties, []string{}, map[string]string{})
+	assert.ErrorIs(t, err, ErrInvalidDeriveFrom)
+	assert.ErrorContains(t, err, "cannot have both a default and derive_from")
+}
+
+func TestNewDerivationsIndexAndVariables(t *testing.T) {
+	properties := []Property{
+		*NewProperty("namespace"),
+		*NewProperty("id", WithDeriveFrom(`{{index . "prefix"}}-{{$.name}}`)),
+		*NewProperty("prefix", WithDeriveFrom(`{{$ns := .namespace}}{{with .stage}}{{$ns}}-{{.}}{{end}}`)),
+		*NewProperty("name"),
+		*NewProperty("stage"),
+	}
+	c, err := NewProviderConfig(properties, []string{}, map[string]string{"namespace": "cp", "stage": "dev"})
+	assert.NoError(t, err)
+
+	resolved, errs := c.ResolveValues(map[string]string{"name": "app"})
+	assert.Empty(t, errs)
+	assert.Equal(t, "cp-dev", resolved["prefix"])
+	assert.Equal(t, "cp-dev-app", resolved["id"])
+
+	properties = []Property{
+		*NewProperty("a", WithDeriveFrom(`{{index . "b"}}`)),
+		*NewProperty("b", WithDeriveFrom("{{$.a}}")),
+	}
+	_, err = NewProviderConfig(properties, []string{}, map[string]string{})
+	assert.ErrorIs(t, err, ErrDerivedValueCycle)
+	assert.ErrorContains(t, err, "a -> b -> a")
+}
+
+func TestNewDerivationsDynamic(t *testing.T) {
+	for _, deriveFrom := range []string{
+		`{{index . .key}}`,
+		`{{printf "%v" .}}`,
+		`{{range $k, $v := .}}{{$v}}{{end}}`,
+		`{{index $ (print "na" "me")}}`,
+	} {
+		t.Run(deriveFrom, func(t *testing.T) {
+			properties := []Property{*NewProperty("name"), *NewProperty("id", WithDeriveFrom(deriveFrom))}
+			_, err := NewProviderConfig(properties, []string{}, map[string]string{})
+			assert.ErrorIs(t, err, ErrInvalidDeriveFrom)
+			assert.ErrorContains(t, err, "must reference values by name")
+		})
+	}
+}
+
+func TestResolveValuesDerivedFromLookup(t *testing.T) {
+	properties := []Property{
+		*NewProperty("region"),
+		*NewProperty("environment", WithDeriveFrom(`{{lookup "aws_region_short" .region}}`)),
+		*NewProperty("tier", WithDeriveFrom(`{{lookup "tiers" .environment}}`)),
+	}
+	lookups := map[string]Lookup{"tiers": {Values: map[string]string{"uw2": "primary"}, Strict: true}}
+	c, err := NewProviderConfig(properties, []string{}, map[string]string{}, WithLookups(lookups))
+	assert.NoError(t, err)
+
+	resolved, errs := c.ResolveValues(map[string]string{"region": "us-west-2"})
+	assert.Empty(t, errs)
+	assert.Equal(t, "uw2", resolved["environment"])
+	assert.Equal(t, "primary", resolved["tier"])
+
+	// A value that is not in a lookup passes through, unless the lookup is strict
+	resolved, errs = c.ResolveValues(map[string]string{"region": "mars-north-1"})
+	assert.Equal(t, "mars-north-1", resolved["environment"])
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrDeriveFailed)
+	assert.ErrorContains(t, errs[0], "value is not in lookup: mars-north-1 in lookup tiers")
+
+	// Nothing is looked up without a value
+	resolved, errs = c.ResolveValues(nil)
+	assert.Empty(t, errs)
+	assert.Empty(t, resolved["environment"])
+	assert.Empty(t, resolved["tier"])
+}
+
+func TestNewDerivationsUnknownLookup(t *testing.T) {
+	properties := []Property{*NewProperty("region"), *NewProperty("environment", WithDeriveFrom(`{{lookup "regions" .region}}`))}
+	_, err := NewProviderConfig(properties, []string{}, map[string]string{})
+	assert.ErrorIs(t, err, ErrInvalidDeriveFrom)
+	assert.ErrorIs(t, err, ErrUnknownLookup)
+	assert.ErrorContains(t, err, `property environment: unknown lookup: "regions"`)
+}
+
+func TestGetMergedValueSources(t *testing.T) {
+	c := getDerivedProviderConfig(t, map[string]string{"namespace": "cp"})
+
+	sources := c.GetMergedValueSources(map[string]string{"name": "app"})
+	assert.Equal(t, map[string]string{
+		"namespace": ValueSourceProvider,
+		"stage":     ValueSourceDefault,
+		"name":      ValueSourceDataSource,
+		"prefix":    ValueSourceDerived,
+		"id":        ValueSourceDerived,
+	}, sources)
+}
+
+func TestDerivedValuesAreValidated(t *testing.T) {
+	c := getDerivedProviderConfig(t, map[string]string{"namespace": "cp"})
+
+	label, errs := c.GetDelimitedLabel(nil, []string{"id"}, []string{"id"}, map[string]string{"name": "app"}, nil, 0, true)
+	assert.Empty(t, errs)
+	assert.Equal(t, "cp-dev-app", label)
+
+	tags, errs := c.GetTags(map[string]string{"name": "app"}, nil, nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, "dev", tags["Stage"])
+	assert.Equal(t, "cp-dev-app", tags["Id"])
+
+	// The default is validated like any other value
+	properties := []Property{*NewProperty("stage", WithDefault("qa"), WithAllowedValues([]AllowedValue{{Value: "dev"}}))}
+	c, err := NewProviderConfig(properties, []string{"stage"}, map[string]string{})
+	assert.NoError(t, err)
+	_, errs = c.GetDelimitedLabel(nil, nil, nil, nil, nil, 0, true)
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrValueNotAllowed)
+}
+
+func TestExplainValuesDerived(t *testing.T) {
+	c := getDerivedProviderConfig(t, map[string]string{"namespace": "cp"})
+
+	explanation := c.ExplainValues()
+	assert.Equal(t, "dev", getExplainedProperty(t, explanation, "stage").Value)
+	assert.Equal(t, ValueSourceDefault, getExplainedProperty(t, explanation, "stage").Source)
+	assert.Equal(t, "cp-dev", getExplainedProperty(t, explanation, "prefix").Value)
+	assert.Equal(t, ValueSourceDerived, getExplainedProperty(t, explanation, "prefix").Source)
+}
@@ -0,0 +1,305 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/cloudposse/terraform-provider-context/pkg/cases"
+	"github.com/cloudposse/terraform-provider-context/pkg/stringHelpers"
+)
+
+var (
+	ErrInvalidEncodedContext     = errors.New("invalid encoded context")
+	ErrUnsupportedContextVersion = errors.New("unsupported encoded context version")
+)
+
+// EncodedContextVersion is the schema version written by Encode. Bump it when a change to the encoding cannot be read
+// by older decoders, and teach DecodeProviderConfig to upgrade the previous versions. New optional fields do not need a
+// new version, since they are left out when unset and read as their zero value from older tokens.
+const EncodedContextVersion = 1
+
+// ValueSourceContextToken marks a value read from the context_token of the provider.
+const ValueSourceContextToken = "context_token"
+
+// encodedContext is the serialized form of a ProviderConfig. Properties are sorted by name and maps are written with
+// sorted keys, so the same context always encodes to the same string.
+type encodedContext struct {
+	Version           int                           `json:"version"`
+	Delimiter         string                        `json:"delimiter"`
+	Enabled           bool                          `json:"enabled"`
+	LabelFormats      map[string]encodedLabelFormat `json:"label_formats,omitempty"`
+	Lookups           map[string]encodedLookup      `json:"lookups,omitempty"`
+	Properties        []encodedProperty             `json:"properties"`
+	PropertyOrder     []string                      `json:"property_order"`
+	ReplaceCharsRegex string                        `json:"replace_chars_regex,omitempty"`
+	TagsKeyCase       string                        `json:"tags_key_case"`
+	TagsValueCase     string                        `json:"tags_value_case"`
+	Values            map[string]string             `json:"values"`
+	ValueSources      map[string]string             `json:"value_sources,omitempty"`
+	Validations       []encodedValidationRule       `json:"validations,omitempty"`
+}
+
+type encodedProperty struct {
+	AllowedValues   []encodedAllowedValue `json:"allowed_values,omitempty"`
+	Default         string                `json:"default,omitempty"`
+	DeriveFrom      string                `json:"derive_from,omitempty"`
+	IncludeInTags   bool                  `json:"include_in_tags"`
+	LabelLookup     string                `json:"label_lookup,omitempty"`
+	MaxLength       int                   `json:"max_length,omitempty"`
+	MinLength       int                   `json:"min_length,omitempty"`
+	Name            string                `json:"name"`
+	Order           int                   `json:"order,omitempty"`
+	Required        bool                  `json:"required,omitempty"`
+	RequiredWhen    map[string][]string   `json:"required_when,omitempty"`
+	Severity        map[string]string     `json:"severity,omitempty"`
+	TagLookup       string                `json:"tag_lookup,omitempty"`
+	TagsKeyCase     string                `json:"tags_key_case,omitempty"`
+	TagsValueCase   string                `json:"tags_value_case,omitempty"`
+	ValidationRegex string                `json:"validation_regex,omitempty"`
+}
+
+type encodedAllowedValue struct {
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+type encodedValidationRule struct {
+	Name         string `json:"name"`
+	Condition    string `json:"condition"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+type encodedLookup struct {
+	Values map[string]string `json:"values"`
+	Strict bool              `json:"strict,omitempty"`
+}
+
+type encodedLabelFormat struct {
+	Delimiter  *string  `json:"delimiter,omitempty"`
+	MaxLength  *int     `json:"max_length,omitempty"`
+	Properties []string `json:"properties,omitempty"`
+	Template   *string  `json:"template,omitempty"`
+	Truncate   *bool    `json:"truncate,omitempty"`
+}
+
+// Encode serializes the context to an opaque string that DecodeProviderConfig turns back into the same context.
+func (c *ProviderConfig) Encode() (string, error) {
+	data, err := json.Marshal(c.toEncodedContext())
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// Hash returns a hash of the settings, properties and values of the context. It leaves out the encoding version and
+// where the values came from, so it only changes when the context itself does.
+func (c *ProviderConfig) Hash() (string, error) {
+	ec := c.toEncodedContext()
+	ec.Version = 0
+	ec.ValueSources = nil
+	data, err := json.Marshal(ec)
+	if err != nil {
+		return "", err
+	}
+	return stringHelpers.HashString(string(data)), nil
+}
+
+// toEncodedContext returns the serialized form of the context.
+func (c *ProviderConfig) toEncodedContext() encodedContext {
+	ec := encodedContext{
+		Version:           EncodedContextVersion,
+		Delimiter:         c.delimiter,
+		Enabled:           c.enabled,
+		LabelFormats:      make(map[string]encodedLabelFormat, len(c.labelFormats)),
+		Lookups:           make(map[string]encodedLookup, len(c.lookups)),
+		Properties:        make([]encodedProperty, 0, len(c.properties)),
+		PropertyOrder:     c.propertyOrder,
+		ReplaceCharsRegex: c.replaceCharsRegex,
+		TagsKeyCase:       c.tagsKeyCase.String(),
+		TagsValueCase:     c.tagsValueCase.String(),
+		Values:            c.values,
+		ValueSources:      c.valueSources,
+	}
+	for name, f := range c.labelFormats {
+		ec.LabelFormats[name] = encodedLabelFormat(f)
+	}
+	for name, l := range c.lookups {
+		ec.Lookups[name] = encodedLookup(l)
+	}
+	for _, r := range c.validations {
+		ec.Validations = append(ec.Validations, encodedValidationRule(r))
+	}
+	properties := append([]Property{}, c.properties...)
+	sort.SliceStable(properties, func(i, j int) bool { return properties[i].Name < properties[j].Name })
+	for _, p := range properties {
+		var allowedValues []encodedAllowedValue
+		for _, av := range p.AllowedValues {
+			allowedValues = append(allowedValues, encodedAllowedValue(av))
+		}
+		ec.Properties = append(ec.Properties, encodedProperty{
+			AllowedValues:   allowedValues,
+			Default:         p.Default,
+			DeriveFrom:      p.DeriveFrom,
+			IncludeInTags:   p.IncludeInTags,
+			LabelLookup:     p.LabelLookup,
+			MaxLength:       p.MaxLength,
+			MinLength:       p.MinLength,
+			Name:            p.Name,
+			Order:           p.Order,
+			Required:        p.Required,
+			RequiredWhen:    p.RequiredWhen,
+			Severity:        getSeverityNames(p.Severities),
+			TagLookup:       p.TagLookup,
+			TagsKeyCase:     getCaseName(p.TagsKeyCase),
+			TagsValueCase:   getCaseName(p.TagsValueCase),
+			ValidationRegex: p.ValidationRegex,
+		})
+	}
+	return ec
+}
+
+// DecodeProviderConfig rebuilds a context serialized with Encode. Tokens without a version predate versioning and are
+// read as version 1.
+func DecodeProviderConfig(encoded string) (*ProviderConfig, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEncodedContext, err)
+	}
+
+	var ec encodedContext
+	if err := json.Unmarshal(data, &ec); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEncodedContext, err)
+	}
+
+	if ec.Version == 0 {
+		ec.Version = 1
+	}
+	if ec.Version > EncodedContextVersion {
+		return nil, fmt.Errorf("%w: %d, the newest supported version is %d, upgrade the provider to read it", ErrUnsupportedContextVersion, ec.Version, EncodedContextVersion)
+	}
+
+	return ec.toProviderConfig()
+}
+
+func (ec *encodedContext) toProviderConfig() (*ProviderConfig, error) {
+	properties := make([]Property, 0, len(ec.Properties))
+	for _, p := range ec.Properties {
+		property, err := p.toProperty()
+		if err != nil {
+			return nil, err
+		}
+		properties = append(properties, *property)
+	}
+
+	tagsKeyCase, err := decodeCaseName(ec.TagsKeyCase)
+	if err != nil {
+		return nil, err
+	}
+	tagsValueCase, err := decodeCaseName(ec.TagsValueCase)
+	if err != nil {
+		return nil, err
+	}
+
+	formats := make(map[string]LabelFormat, len(ec.LabelFormats))
+	for name, f := range ec.LabelFormats {
+		formats[name] = LabelFormat(f)
+	}
+
+	values := ec.Values
+	if values == nil {
+		values = map[string]string{}
+	}
+
+	lookups := make(map[string]Lookup, len(ec.Lookups))
+	for name, l := range ec.Lookups {
+		lookups[name] = Lookup(l)
+	}
+
+	rules := make([]ValidationRule, 0, len(ec.Validations))
+	for _, r := range ec.Validations {
+		rules = append(rules, ValidationRule(r))
+	}
+
+	return NewProviderConfig(properties, ec.PropertyOrder, values,
+		WithDelimiter(ec.Delimiter),
+		WithEnabled(ec.Enabled),
+		WithLabelFormats(formats),
+		WithLookups(lookups),
+		WithReplaceCharsRegex(ec.ReplaceCharsRegex),
+		WithTagsKeyCase(tagsKeyCase),
+		WithTagsValueCase(tagsValueCase),
+		WithValueSources(ec.ValueSources),
+		WithValidationRules(rules),
+	)
+}
+
+func (p encodedProperty) toProperty() (*Property, error) {
+	property := &Property{
+		Default:         p.Default,
+		DeriveFrom:      p.DeriveFrom,
+		IncludeInTags:   p.IncludeInTags,
+		LabelLookup:     p.LabelLookup,
+		MaxLength:       p.MaxLength,
+		MinLength:       p.MinLength,
+		Name:            p.Name,
+		Order:           p.Order,
+		Required:        p.Required,
+		RequiredWhen:    p.RequiredWhen,
+		TagLookup:       p.TagLookup,
+		ValidationRegex: p.ValidationRegex,
+	}
+	for _, av := range p.AllowedValues {
+		property.AllowedValues = append(property.AllowedValues, AllowedValue(av))
+	}
+	if len(p.Severity) > 0 {
+		severities, err := NewSeverities(p.Severity)
+		if err != nil {
+			return nil, err
+		}
+		property.Severities = severities
+	}
+	if p.TagsKeyCase != "" {
+		c, err := decodeCaseName(p.TagsKeyCase)
+		if err != nil {
+			return nil, err
+		}
+		property.TagsKeyCase = &c
+	}
+	if p.TagsValueCase != "" {
+		c, err := decodeCaseName(p.TagsValueCase)
+		if err != nil {
+			return nil, err
+		}
+		property.TagsValueCase = &c
+	}
+	return property, nil
+}
+
+func getSeverityNames(severities map[string]Severity) map[string]string {
+	if len(severities) == 0 {
+		return nil
+	}
+	names := make(map[string]string, len(severities))
+	for check, severity := range severities {
+		names[check] = string(severity)
+	}
+	return names
+}
+
+func getCaseName(c *cases.Case) string {
+	if c == nil {
+		return ""
+	}
+	return c.String()
+}
+
+func decodeCaseName(name string) (cases.Case, error) {
+	c, err := cases.FromString(name)
+	if err != nil {
+		return cases.Unknown, fmt.Errorf("%w: %w", ErrInvalidEncodedContext, err)
+	}
+	return c, nil
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"encoding/base64"
+	"errors"
+	"maps"
+	"testing"
+
+	"github.com/cloudposse/terraform-provider-context/pkg/cases"
+	"github.com/stretchr/testify/assert"
+)
+
+func getEncodingProviderConfig(t *testing.T) *ProviderConfig {
+	t.Helper()
+	template := "{{.tenant}}-{{.stage}}"
+	properties := []Property{
+		*NewProperty("namespace", WithRequired(), WithMinLength(2), WithMaxLength(6), WithOrder(1), WithValidationRegex("^[a-z]+$")),
+		*NewProperty("stage", WithOrder(3), WithExcludeFromTags(), WithPropertyTagsValueCase(cases.LowerCase),
+			WithAllowedValues([]AllowedValue{{Value: "dev"}, {Value: "prod", Description: "Production"}})),
+		*NewProperty("tenant", WithOrder(2), WithPropertyTagsKeyCase(cases.UpperCase), WithSeverities(map[string]Severity{CheckRequired: SeverityWarning}),
+			WithLabelLookup("tenants")),
+	}
+	c, err := NewProviderConfig(properties, []string{"namespace", "stage"}, map[string]string{"namespace": "cp", "tenant": "core", "stage": "prod"},
+		WithDelimiter("_"),
+		WithLabelFormats(map[string]LabelFormat{"stack": {Template: &template}}),
+		WithLookups(map[string]Lookup{"tenants": {Values: map[string]string{"platform": "plat"}}, "stages": {Values: map[string]string{"prod": "p"}, Strict: true}}),
+		WithReplaceCharsRegex("[^a-z_]"),
+		WithTagsKeyCase(cases.SnakeCase),
+		WithTagsValueCase(cases.UpperCase),
+		WithValueSources(map[string]string{"namespace": ValueSourceConfigFile}),
+		WithValidationRules([]ValidationRule{{Name: "length", Condition: "{{ le (len .namespace) 6 }}", ErrorMessage: "too long"}}),
+	)
+	assert.NoError(t, err)
+	return c
+}
+
+func TestProviderConfigEncodeRoundTrip(t *testing.T) {
+	c := getEncodingProviderConfig(t)
+
+	encoded, err := c.Encode()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, encoded)
+
+	decoded, err := DecodeProviderConfig(encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, c, decoded)
+
+	again, err := decoded.Encode()
+	assert.NoError(t, err)
+	assert.Equal(t, encoded, again)
+}
+
+func TestProviderConfigEncodeCanonical(t *testing.T) {
+	c := getEncodingProviderConfig(t)
+	reversed := []Property{c.GetProperties()[2], c.GetProperties()[1], c.GetProperties()[0]}
+	other, err := NewProviderConfig(reversed, c.GetPropertyOrder(), c.GetValues(), c.Options()...)
+	assert.NoError(t, err)
+	other.valueSources = c.valueSources
+
+	expected, err := c.Encode()
+	assert.NoError(t, err)
+	actual, err := other.Encode()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestProviderConfigHash(t *testing.T) {
+	c := getEncodingProviderConfig(t)
+	hash, err := c.Hash()
+	assert.NoError(t, err)
+	assert.Regexp(t, "^[0-9a-f]{64}$", hash)
+
+	// Where the values came from does not change the hash
+	same, err := NewProviderConfig(c.GetProperties(), c.GetPropertyOrder(), c.GetValues(), c.Options()...)
+	assert.NoError(t, err)
+	same.valueSources = map[string]string{"namespace": ValueSourceEnvironment}
+	sameHash, err := same.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, hash, sameHash)
+
+	values := maps.Clone(c.GetValues())
+	values["stage"] = "dev"
+	other, err := NewProviderConfig(c.GetProperties(), c.GetPropertyOrder(), values, c.Options()...)
+	assert.NoError(t, err)
+	otherHash, err := other.Hash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash)
+}
+
+func TestDecodeProviderConfigVersions(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	// Tokens written before the version field was added are read as version 1
+	c, err := DecodeProviderConfig(encode(`{"delimiter":"_","enabled":true,"properties":[{"name":"stage","include_in_tags":true}],"property_order":["stage"],"tags_key_case":"title","tags_value_case":"none","values":{"stage":"prod"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "_", c.GetDelimiter())
+	assert.Equal(t, map[string]string{"stage": "prod"}, c.GetValues())
+
+	// Fields added by newer versions of the same schema version are ignored
+	_, err = DecodeProviderConfig(encode(`{"version":1,"tags_key_case":"title","tags_value_case":"none","future_option":true}`))
+	assert.NoError(t, err)
+
+	_, err = DecodeProviderConfig(encode(`{"version":2}`))
+	assert.True(t, errors.Is(err, ErrUnsupportedContextVersion))
+}
+
+func TestDecodeProviderConfigInvalid(t *testing.T) {
+	for name, encoded := range map[string]string{
+		"not base64": "not base64!",
+		"not json":   "bm90IGpzb24",
+		"bad case":   "eyJ0YWdzX2tleV9jYXNlIjoiYm9ndXMifQ",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := DecodeProviderConfig(encoded)
+			assert.True(t, errors.Is(err, ErrInvalidEncodedContext))
+		})
+	}
+}
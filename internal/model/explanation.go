@@ -0,0 +1,339 @@
+package model
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/cloudposse/terraform-provider-context/pkg/slice"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// ValueSourceDataSource marks a value set in the values of a data source.
+	ValueSourceDataSource = "data_source"
+	// ValueSourceUnset marks a property without a value.
+	ValueSourceUnset = "unset"
+
+	// ReasonIncluded marks a property that is part of the output.
+	ReasonIncluded = "included"
+	// ReasonEmptyValue marks a property that is left out because it has no value.
+	ReasonEmptyValue = "empty value"
+	// ReasonNotInProperties marks a property that is left out because it is not in the label's properties.
+	ReasonNotInProperties = "not in properties"
+	// ReasonNotInPropertyOrder marks a property that is left out because it is not in the property order.
+	ReasonNotInPropertyOrder = "not in property order"
+	// ReasonNotInTemplate marks a property that is left out because the label template does not reference it.
+	ReasonNotInTemplate = "not in template"
+	// ReasonExcludedFromTags marks a property that is left out of tags because include_in_tags is false.
+	ReasonExcludedFromTags = "excluded from tags"
+)
+
+// Explanation is a trace of how a label, tags or the context were built from the properties and values.
+type Explanation struct {
+	Properties  []PropertyExplanation `tfsdk:"properties"`
+	Removed     string                `tfsdk:"removed"`
+	Truncated   bool                  `tfsdk:"truncated"`
+	Untruncated string                `tfsdk:"untruncated"`
+}
+
+// PropertyExplanation describes where the value of a property came from and what happened to it. Removed is what the
+// replace regex matches in the value on its own. The regex is applied to the whole label, so the Removed of the
+// Explanation is what was actually removed.
+type PropertyExplanation struct {
+	Name     string `tfsdk:"name"`
+	Value    string `tfsdk:"value"`
+	Source   string `tfsdk:"source"`
+	Included bool   `tfsdk:"included"`
+	Reason   string `tfsdk:"reason"`
+	Removed  string `tfsdk:"removed"`
+}
+
+// ExplanationAttrTypes returns the framework types of an Explanation.
+func ExplanationAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"properties": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+			"name":     types.StringType,
+			"value":    types.StringType,
+			"source":   types.StringType,
+			"included": types.BoolType,
+			"reason":   types.StringType,
+			"removed":  types.StringType,
+		}}},
+		"removed":     types.StringType,
+		"truncated":   types.BoolType,
+		"untruncated": types.StringType,
+	}
+}
+
+// clone returns a copy of the explanation that shares nothing with it.
+func (e *Explanation) clone() *Explanation {
+	if e == nil {
+		return nil
+	}
+	c := *e
+	c.Properties = slices.Clone(e.Properties)
+	return &c
+}
+
+// IncludedValues returns the values of the properties that are part of the output, keyed by property name.
+func (e *Explanation) IncludedValues() map[string]string {
+	values := map[string]string{}
+	if e == nil {
+		return values
+	}
+	for _, p := range e.Properties {
+		if p.Included {
+			values[p.Name] = p.Value
+		}
+	}
+	return values
+}
+
+// ToFramework converts the explanation to a framework object. The object is null unless explain is true, so the trace
+// is only stored in state when it was asked for. The explanation is not modified, so it may be shared.
+func (e *Explanation) ToFramework(ctx context.Context, explain types.Bool) (types.Object, diag.Diagnostics) {
+	if e == nil || !explain.ValueBool() {
+		return types.ObjectNull(ExplanationAttrTypes()), nil
+	}
+	out := *e
+	if out.Properties == nil {
+		out.Properties = []PropertyExplanation{}
+	}
+	return types.ObjectValueFrom(ctx, ExplanationAttrTypes(), out)
+}
+
+// getValueSource returns where the merged value of a property came from.
+func (c *ProviderConfig) getValueSource(name string, localValues map[string]string) string {
+	value, ok := localValues[name]
+	source := ValueSourceDataSource
+	if !ok {
+		value, ok = c.values[name]
+		source = c.GetValueSources()[name]
+	}
+	if value == "" {
+		if filledSource, filled := c.getFilledValueSource(name); filled {
+			return filledSource
+		}
+	}
+	if ok {
+		return source
+	}
+	return ValueSourceUnset
+}
+
+// getExplainedNames returns the names in the given order followed by every other property of the context.
+func (c *ProviderConfig) getExplainedNames(order []string) []string {
+	names := append([]string{}, order...)
+	for _, p := range c.properties {
+		if !slice.Contains(names, p.Name) {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+// explainProperty describes the value of a property. The reason and inclusion are left to the caller.
+func (c *ProviderConfig) explainProperty(name string, mergedValues map[string]string, localValues map[string]string, regex *regexp.Regexp) PropertyExplanation {
+	value := mergedValues[name]
+	explanation := PropertyExplanation{
+		Name:   name,
+		Value:  value,
+		Source: c.getValueSource(name, localValues),
+	}
+	if regex != nil {
+		explanation.Removed = strings.Join(regex.FindAllString(value, -1), "")
+	}
+	return explanation
+}
+
+// explainDelimitedLabel describes which properties end up in a delimited label.
+func (c *ProviderConfig) explainDelimitedLabel(properties []string, propertyOrder []string, mergedValues map[string]string, localValues map[string]string, regex *regexp.Regexp) *Explanation {
+	explanation := &Explanation{}
+	for _, name := range c.getExplainedNames(propertyOrder) {
+		pe := c.explainProperty(name, mergedValues, localValues, regex)
+		switch {
+		case !slice.Contains(properties, name):
+			pe.Reason = ReasonNotInProperties
+		case !slice.Contains(propertyOrder, name):
+			pe.Reason = ReasonNotInPropertyOrder
+		case pe.Value == "":
+			pe.Reason = ReasonEmptyValue
+		default:
+			pe.Included = true
+			pe.Reason = ReasonIncluded
+		}
+		explanation.Properties = append(explanation.Properties, pe)
+	}
+	return explanation
+}
+
+// explainTemplatedLabel describes which properties end up in a templated label.
+func (c *ProviderConfig) explainTemplatedLabel(tmpl *template.Template, mergedValues map[string]string, localValues map[string]string, regex *regexp.Regexp) *Explanation {
+	fields := getTemplateFields(tmpl)
+	explanation := &Explanation{}
+	for _, name := range c.getExplainedNames(fields) {
+		pe := c.explainProperty(name, mergedValues, localValues, regex)
+		switch {
+		case !slice.Contains(fields, name):
+			pe.Reason = ReasonNotInTemplate
+		case pe.Value == "":
+			pe.Reason = ReasonEmptyValue
+		default:
+			pe.Included = true
+			pe.Reason = ReasonIncluded
+		}
+		explanation.Properties = append(explanation.Properties, pe)
+	}
+	return explanation
+}
+
+// ExplainValues describes the values of the context and whether each property is part of the property order.
+func (c *ProviderConfig) ExplainValues() *Explanation {
+	explanation := &Explanation{}
+	mergedValues := c.GetMergedValues(nil)
+	for _, name := range c.getExplainedNames(c.propertyOrder) {
+		pe := c.explainProperty(name, mergedValues, nil, nil)
+		switch {
+		case !slice.Contains(c.propertyOrder, name):
+			pe.Reason = ReasonNotInPropertyOrder
+		case pe.Value == "":
+			pe.Reason = ReasonEmptyValue
+		default:
+			pe.Included = true
+			pe.Reason = ReasonIncluded
+		}
+		explanation.Properties = append(explanation.Properties, pe)
+	}
+	return explanation
+}
+
+// templateFields are the fields referenced by a template.
+type templateFields struct {
+	// names are the names of the fields, in the order they first appear.
+	names []string
+	// lookups are the names of the lookups passed to the lookup function, e.g. "regions" for {{lookup "regions" .region}}.
+	lookups []string
+	// dynamic is true when the template uses the values as a whole, e.g. {{index . $name}} or {{printf "%v" .}}, so
+	// the fields it references are not known until it is executed.
+	dynamic bool
+}
+
+// getTemplateFields returns the names of the fields referenced by a template, e.g. "name" for {{.name}}, {{$.name}} or
+// {{index . "name"}}, in the order they first appear.
+func getTemplateFields(tmpl *template.Template) []string {
+	return collectTemplateFields(tmpl).names
+}
+
+// collectTemplateFields walks the parse tree of a template and returns the fields it references.
+func collectTemplateFields(tmpl *template.Template) *templateFields {
+	fields := &templateFields{names: []string{}, lookups: []string{}}
+	if tmpl != nil && tmpl.Tree != nil {
+		fields.collect(tmpl.Root, false)
+	}
+	return fields
+}
+
+// add adds the name of a field unless it was already added.
+func (f *templateFields) add(name string) {
+	if !slice.Contains(f.names, name) {
+		f.names = append(f.names, name)
+	}
+}
+
+// collect adds the fields referenced by a node. Inside range and with, dot is rebound to another value, so only $
+// still refers to the values there.
+func (f *templateFields) collect(node parse.Node, rebound bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			f.collect(child, rebound)
+		}
+	case *parse.ActionNode:
+		f.collect(n.Pipe, rebound)
+	case *parse.TemplateNode:
+		f.collect(n.Pipe, rebound)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			f.collectCommand(cmd, rebound)
+		}
+	case *parse.ChainNode:
+		f.collect(n.Node, rebound)
+	case *parse.FieldNode:
+		f.add(n.Ident[0])
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			f.add(n.Ident[1])
+		} else if n.Ident[0] == "$" {
+			f.dynamic = true
+		}
+	case *parse.DotNode:
+		f.dynamic = f.dynamic || !rebound
+	case *parse.IfNode:
+		f.collectBranch(&n.BranchNode, rebound, rebound)
+	case *parse.RangeNode:
+		f.collectBranch(&n.BranchNode, rebound, true)
+	case *parse.WithNode:
+		f.collectBranch(&n.BranchNode, rebound, true)
+	}
+}
+
+// collectCommand adds the fields referenced by the arguments of a command. Indexing the values with a literal, as in
+// {{index . "name"}}, references that field, while indexing them with anything else makes the template dynamic.
+func (f *templateFields) collectCommand(cmd *parse.CommandNode, rebound bool) {
+	args := cmd.Args
+	if len(args) >= 3 && isTemplateIdentifier(args[0], "index") && isTemplateValues(args[1], rebound) {
+		if key, ok := args[2].(*parse.StringNode); ok {
+			f.add(key.Text)
+		} else {
+			f.collect(args[2], rebound)
+			f.dynamic = true
+		}
+		args = args[3:]
+	}
+	if len(args) >= 2 && isTemplateIdentifier(args[0], "lookup") {
+		if name, ok := args[1].(*parse.StringNode); ok && !slice.Contains(f.lookups, name.Text) {
+			f.lookups = append(f.lookups, name.Text)
+		}
+	}
+	for _, arg := range args {
+		f.collect(arg, rebound)
+	}
+}
+
+// collectBranch adds the fields referenced by an if, range or with. The body of range and with is executed with dot
+// rebound, while their else branch is not.
+func (f *templateFields) collectBranch(n *parse.BranchNode, rebound bool, reboundInList bool) {
+	f.collect(n.Pipe, rebound)
+	f.collect(n.List, reboundInList)
+	f.collect(n.ElseList, rebound)
+}
+
+// isTemplateIdentifier returns true when the node is the function with the given name.
+func isTemplateIdentifier(node parse.Node, name string) bool {
+	identifier, ok := node.(*parse.IdentifierNode)
+	return ok && identifier.Ident == name
+}
+
+// isTemplateValues returns true when the node is the values the template is executed with, that is dot where it is
+// not rebound, or $.
+func isTemplateValues(node parse.Node, rebound bool) bool {
+	switch n := node.(type) {
+	case *parse.DotNode:
+		return !rebound
+	case *parse.VariableNode:
+		return len(n.Ident) == 1 && n.Ident[0] == "$"
+	}
+	return false
+}
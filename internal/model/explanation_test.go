@@ -0,0 +1,143 @@
+package model
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func getExplainedProperty(t *testing.T, explanation *Explanation, name string) PropertyExplanation {
+	t.Helper()
+	for _, p := range explanation.Properties {
+		if p.Name == name {
+			return p
+		}
+	}
+	t.Fatalf("property %q not in explanation", name)
+	return PropertyExplanation{}
+}
+
+func TestProviderConfigExplainDelimitedLabel(t *testing.T) {
+	c := getDefaultProviderConfig(t, true)
+	regex := "[a]"
+	label, explanation, errs := c.ExplainDelimitedLabel(nil, nil, []string{"bar", "foo"}, map[string]string{"foo": "fooo"}, &regex, 0, false)
+	assert.Empty(t, errs)
+	assert.Equal(t, "br-fooo", label)
+	assert.False(t, explanation.Truncated)
+	assert.Equal(t, "br-fooo", explanation.Untruncated)
+	assert.Equal(t, "a", explanation.Removed)
+
+	assert.Equal(t, []string{"bar", "foo", "baz"}, []string{explanation.Properties[0].Name, explanation.Properties[1].Name, explanation.Properties[2].Name})
+	assert.Equal(t, PropertyExplanation{Name: "bar", Value: "bar", Source: ValueSourceProvider, Included: true, Reason: ReasonIncluded, Removed: "a"}, getExplainedProperty(t, explanation, "bar"))
+	assert.Equal(t, PropertyExplanation{Name: "foo", Value: "fooo", Source: ValueSourceDataSource, Included: true, Reason: ReasonIncluded}, getExplainedProperty(t, explanation, "foo"))
+	assert.Equal(t, ReasonNotInPropertyOrder, getExplainedProperty(t, explanation, "baz").Reason)
+}
+
+func TestProviderConfigExplainRemovedFromJoinedLabel(t *testing.T) {
+	c := getDefaultProviderConfig(t, true)
+
+	// The regex also removes the delimiters, which no property value contains
+	regex := "[-o]"
+	label, explanation, errs := c.ExplainDelimitedLabel(nil, nil, nil, nil, &regex, 0, false)
+	assert.Empty(t, errs)
+	assert.Equal(t, "fbarbaz", label)
+	assert.Equal(t, "oo--", explanation.Removed)
+	assert.Equal(t, "oo", getExplainedProperty(t, explanation, "foo").Removed)
+	assert.Empty(t, getExplainedProperty(t, explanation, "bar").Removed)
+}
+
+func TestProviderConfigExplainDelimitedLabelWithLocalProperties(t *testing.T) {
+	c := getDefaultProviderConfig(t, true)
+	_, explanation, errs := c.ExplainDelimitedLabel(nil, []string{"foo"}, nil, map[string]string{"bar": ""}, nil, 0, false)
+	assert.Empty(t, errs)
+	assert.Equal(t, ReasonIncluded, getExplainedProperty(t, explanation, "foo").Reason)
+	assert.Equal(t, ReasonNotInProperties, getExplainedProperty(t, explanation, "bar").Reason)
+	assert.False(t, getExplainedProperty(t, explanation, "bar").Included)
+}
+
+func TestProviderConfigExplainDelimitedLabelWithTruncation(t *testing.T) {
+	c := getDefaultProviderConfig(t, true)
+	label, explanation, errs := c.ExplainDelimitedLabel(nil, nil, nil, nil, nil, 10, true)
+	assert.Empty(t, errs)
+	assert.Equal(t, "foo-ba6094", label)
+	assert.True(t, explanation.Truncated)
+	assert.Equal(t, "foo-bar-baz", explanation.Untruncated)
+}
+
+func TestProviderConfigExplainDelimitedLabelWithEmptyValue(t *testing.T) {
+	c := getDefaultProviderConfig(t, true)
+	label, explanation, errs := c.ExplainDelimitedLabel(nil, nil, nil, map[string]string{"bar": ""}, nil, 0, false)
+	assert.Empty(t, errs)
+	assert.Equal(t, "foo-baz", label)
+	assert.Equal(t, PropertyExplanation{Name: "bar", Source: ValueSourceDataSource, Reason: ReasonEmptyValue}, getExplainedProperty(t, explanation, "bar"))
+}
+
+func TestProviderConfigExplainTemplatedLabel(t *testing.T) {
+	c := getDefaultProviderConfig(t, true)
+	label, explanation, errs := c.ExplainTemplatedLabel("{{.baz}}/{{if .qux}}{{.qux}}{{else}}{{.foo}}{{end}}", nil, nil, 0, false)
+	assert.Empty(t, errs)
+	assert.Equal(t, "baz/foo", label)
+	assert.Equal(t, ReasonIncluded, getExplainedProperty(t, explanation, "baz").Reason)
+	assert.Equal(t, ReasonIncluded, getExplainedProperty(t, explanation, "foo").Reason)
+	assert.Equal(t, PropertyExplanation{Name: "qux", Source: ValueSourceUnset, Reason: ReasonEmptyValue}, getExplainedProperty(t, explanation, "qux"))
+	assert.Equal(t, ReasonNotInTemplate, getExplainedProperty(t, explanation, "bar").Reason)
+}
+
+func TestProviderConfigExplainTags(t *testing.T) {
+	properties := []Property{*NewProperty("foo"), *NewProperty("bar", WithExcludeFromTags())}
+	c, err := NewProviderConfig(properties, nil, map[string]string{"foo": "foo", "bar": "bar"}, WithValueSources(map[string]string{"foo": ValueSourceConfigFile}))
+	assert.NoError(t, err)
+
+	tags, explanation, errs := c.ExplainTags(nil, nil, nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]string{"Foo": "foo"}, tags)
+	assert.Equal(t, PropertyExplanation{Name: "foo", Value: "foo", Source: ValueSourceConfigFile, Included: true, Reason: ReasonIncluded}, getExplainedProperty(t, explanation, "foo"))
+	assert.Equal(t, ReasonExcludedFromTags, getExplainedProperty(t, explanation, "bar").Reason)
+}
+
+func TestProviderConfigExplainValues(t *testing.T) {
+	properties := []Property{*NewProperty("foo"), *NewProperty("bar")}
+	c, err := NewProviderConfig(properties, []string{"foo"}, map[string]string{"foo": "foo", "bar": "bar"})
+	assert.NoError(t, err)
+
+	explanation := c.ExplainValues()
+	assert.Equal(t, ReasonIncluded, getExplainedProperty(t, explanation, "foo").Reason)
+	assert.Equal(t, ReasonNotInPropertyOrder, getExplainedProperty(t, explanation, "bar").Reason)
+}
+
+func TestExplanationIncludedValues(t *testing.T) {
+	e := &Explanation{Properties: []PropertyExplanation{
+		{Name: "namespace", Value: "cp", Included: true},
+		{Name: "stage", Value: "", Included: false, Reason: ReasonEmptyValue},
+		{Name: "name", Value: "api", Included: true},
+	}}
+	assert.Equal(t, map[string]string{"namespace": "cp", "name": "api"}, e.IncludedValues())
+
+	var empty *Explanation
+	assert.Equal(t, map[string]string{}, empty.IncludedValues())
+}
+
+func TestExplanationToFrameworkConcurrently(t *testing.T) {
+	c, err := NewProviderConfig([]Property{}, []string{}, map[string]string{})
+	assert.NoError(t, err)
+	_, explanation, errs := c.ExplainDelimitedLabel(nil, nil, nil, nil, nil, 0, false)
+	assert.Empty(t, errs)
+	assert.Nil(t, explanation.Properties)
+
+	// Run with -race: explanations may be shared, so converting one must not write to it
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			obj, diags := explanation.ToFramework(context.Background(), types.BoolValue(true))
+			assert.False(t, diags.HasError(), diags)
+			assert.False(t, obj.Attributes()["properties"].IsNull())
+		}()
+	}
+	wg.Wait()
+	assert.Nil(t, explanation.Properties)
+}
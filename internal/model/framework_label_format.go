@@ -0,0 +1,41 @@
+package model
+
+import (
+	"context"
+
+	"github.com/cloudposse/terraform-provider-context/internal/framework"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// FrameworkLabelFormat describes a label format in the provider configuration.
+type FrameworkLabelFormat struct {
+	Delimiter  types.String `tfsdk:"delimiter"`
+	MaxLength  types.Int64  `tfsdk:"max_length"`
+	Properties types.List   `tfsdk:"properties"`
+	Template   types.String `tfsdk:"template"`
+	Truncate   types.Bool   `tfsdk:"truncate"`
+}
+
+func (f *FrameworkLabelFormat) ToModel(ctx context.Context) (LabelFormat, diag.Diagnostics) {
+	format := LabelFormat{
+		Delimiter: f.Delimiter.ValueStringPointer(),
+		Template:  f.Template.ValueStringPointer(),
+		Truncate:  f.Truncate.ValueBoolPointer(),
+	}
+
+	if !f.MaxLength.IsNull() && !f.MaxLength.IsUnknown() {
+		maxLength := int(f.MaxLength.ValueInt64())
+		format.MaxLength = &maxLength
+	}
+
+	if !f.Properties.IsNull() {
+		properties, diags := framework.FromFrameworkList[string](ctx, f.Properties)
+		if diags.HasError() {
+			return format, diags
+		}
+		format.Properties = properties
+	}
+
+	return format, nil
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"context"
+
+	"github.com/cloudposse/terraform-provider-context/internal/framework"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// FrameworkLookup describes a lookup in the provider configuration.
+type FrameworkLookup struct {
+	Strict types.Bool `tfsdk:"strict"`
+	Values types.Map  `tfsdk:"values"`
+}
+
+func (l *FrameworkLookup) ToModel(ctx context.Context) (Lookup, diag.Diagnostics) {
+	values, diags := framework.FromFrameworkMap[string](ctx, l.Values)
+	if diags.HasError() {
+		return Lookup{}, diags
+	}
+
+	return Lookup{
+		Values: values,
+		Strict: l.Strict.ValueBool(),
+	}, nil
+}
@@ -1,19 +1,30 @@
 package model
 
 import (
+	"fmt"
+
 	"github.com/cloudposse/terraform-provider-context/pkg/cases"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type FrameworkProperty struct {
-	IncludeInTags   types.Bool   `tfsdk:"include_in_tags"`
-	MaxLength       types.Int64  `tfsdk:"max_length"`
-	MinLength       types.Int64  `tfsdk:"min_length"`
-	Required        types.Bool   `tfsdk:"required"`
-	TagsKeyCase     types.String `tfsdk:"tags_key_case"`
-	TagsValueCase   types.String `tfsdk:"tags_value_case"`
-	ValidationRegex types.String `tfsdk:"validation_regex"`
+	AllowedValueDescriptions types.Map    `tfsdk:"allowed_value_descriptions"`
+	AllowedValues            types.List   `tfsdk:"allowed_values"`
+	Default                  types.String `tfsdk:"default"`
+	DeriveFrom               types.String `tfsdk:"derive_from"`
+	IncludeInTags            types.Bool   `tfsdk:"include_in_tags"`
+	LabelLookup              types.String `tfsdk:"label_lookup"`
+	MaxLength                types.Int64  `tfsdk:"max_length"`
+	MinLength                types.Int64  `tfsdk:"min_length"`
+	Order                    types.Int64  `tfsdk:"order"`
+	Required                 types.Bool   `tfsdk:"required"`
+	RequiredWhen             types.Map    `tfsdk:"required_when"`
+	Severity                 types.Map    `tfsdk:"severity"`
+	TagLookup                types.String `tfsdk:"tag_lookup"`
+	TagsKeyCase              types.String `tfsdk:"tags_key_case"`
+	TagsValueCase            types.String `tfsdk:"tags_value_case"`
+	ValidationRegex          types.String `tfsdk:"validation_regex"`
 }
 
 func (p *FrameworkProperty) addRequiredOption(options []PropertyOption) []PropertyOption {
@@ -23,6 +34,26 @@ func (p *FrameworkProperty) addRequiredOption(options []PropertyOption) []Proper
 	return options
 }
 
+func (p *FrameworkProperty) addRequiredWhenOption(options []PropertyOption) []PropertyOption {
+	if p.RequiredWhen.IsNull() || p.RequiredWhen.IsUnknown() {
+		return options
+	}
+
+	conditions := map[string][]string{}
+	for name, v := range p.RequiredWhen.Elements() {
+		patterns := []string{}
+		if l, ok := v.(types.List); ok {
+			for _, e := range l.Elements() {
+				if s, ok := e.(types.String); ok {
+					patterns = append(patterns, s.ValueString())
+				}
+			}
+		}
+		conditions[name] = patterns
+	}
+	return append(options, WithRequiredWhen(conditions))
+}
+
 func (p *FrameworkProperty) addIncludeInTagsOption(options []PropertyOption) []PropertyOption {
 	if !p.IncludeInTags.IsNull() && !p.IncludeInTags.IsUnknown() && !p.IncludeInTags.ValueBool() {
 		return append(options, WithExcludeFromTags())
@@ -44,6 +75,13 @@ func (p *FrameworkProperty) addMaxLengthOption(options []PropertyOption) []Prope
 	return options
 }
 
+func (p *FrameworkProperty) addOrderOption(options []PropertyOption) []PropertyOption {
+	if !p.Order.IsNull() && !p.Order.IsUnknown() {
+		return append(options, WithOrder(int(p.Order.ValueInt64())))
+	}
+	return options
+}
+
 func (p *FrameworkProperty) addValidationRegexOption(options []PropertyOption) []PropertyOption {
 	if !p.ValidationRegex.IsNull() && !p.ValidationRegex.IsUnknown() {
 		return append(options, WithValidationRegex(p.ValidationRegex.ValueString()))
@@ -51,6 +89,74 @@ func (p *FrameworkProperty) addValidationRegexOption(options []PropertyOption) [
 	return options
 }
 
+func (p *FrameworkProperty) addDefaultOption(options []PropertyOption) []PropertyOption {
+	if !p.Default.IsNull() && !p.Default.IsUnknown() {
+		return append(options, WithDefault(p.Default.ValueString()))
+	}
+	return options
+}
+
+func (p *FrameworkProperty) addDeriveFromOption(options []PropertyOption) []PropertyOption {
+	if !p.DeriveFrom.IsNull() && !p.DeriveFrom.IsUnknown() {
+		return append(options, WithDeriveFrom(p.DeriveFrom.ValueString()))
+	}
+	return options
+}
+
+func (p *FrameworkProperty) addLookupOptions(options []PropertyOption) []PropertyOption {
+	if !p.LabelLookup.IsNull() && !p.LabelLookup.IsUnknown() {
+		options = append(options, WithLabelLookup(p.LabelLookup.ValueString()))
+	}
+	if !p.TagLookup.IsNull() && !p.TagLookup.IsUnknown() {
+		options = append(options, WithTagLookup(p.TagLookup.ValueString()))
+	}
+	return options
+}
+
+func (p *FrameworkProperty) addAllowedValuesOption(options []PropertyOption) ([]PropertyOption, error) {
+	if p.AllowedValues.IsNull() || p.AllowedValues.IsUnknown() {
+		return options, nil
+	}
+
+	values := []string{}
+	for _, v := range p.AllowedValues.Elements() {
+		if s, ok := v.(types.String); ok {
+			values = append(values, s.ValueString())
+		}
+	}
+	descriptions := map[string]string{}
+	for k, v := range p.AllowedValueDescriptions.Elements() {
+		if s, ok := v.(types.String); ok {
+			descriptions[k] = s.ValueString()
+		}
+	}
+
+	allowedValues, err := NewAllowedValues(values, descriptions)
+	if err != nil {
+		return nil, err
+	}
+	return append(options, WithAllowedValues(allowedValues)), nil
+}
+
+func (p *FrameworkProperty) addSeveritiesOption(options []PropertyOption) ([]PropertyOption, error) {
+	if p.Severity.IsNull() || p.Severity.IsUnknown() {
+		return options, nil
+	}
+
+	names := map[string]string{}
+	for check, v := range p.Severity.Elements() {
+		if s, ok := v.(types.String); ok {
+			names[check] = s.ValueString()
+		}
+	}
+
+	severities, err := NewSeverities(names)
+	if err != nil {
+		return nil, err
+	}
+	return append(options, WithSeverities(severities)), nil
+}
+
 func (p *FrameworkProperty) addTagsKeyCaseOption(options []PropertyOption) []PropertyOption {
 	if !p.TagsKeyCase.IsNull() && !p.TagsKeyCase.IsUnknown() {
 		if caseType, err := cases.FromString(p.TagsKeyCase.ValueString()); err == nil {
@@ -73,35 +179,97 @@ func (p *FrameworkProperty) ToModel(name string) (*Property, error) {
 	options := []PropertyOption{}
 
 	options = p.addRequiredOption(options)
+	options = p.addRequiredWhenOption(options)
 	options = p.addIncludeInTagsOption(options)
 	options = p.addMinLengthOption(options)
 	options = p.addMaxLengthOption(options)
+	options = p.addOrderOption(options)
 	options = p.addValidationRegexOption(options)
+	options = p.addDefaultOption(options)
+	options = p.addDeriveFromOption(options)
+	options = p.addLookupOptions(options)
 	options = p.addTagsKeyCaseOption(options)
 	options = p.addTagsValueCaseOption(options)
 
+	options, err := p.addAllowedValuesOption(options)
+	if err != nil {
+		return nil, fmt.Errorf("property %s: %w", name, err)
+	}
+	options, err = p.addSeveritiesOption(options)
+	if err != nil {
+		return nil, fmt.Errorf("property %s: %w", name, err)
+	}
+
 	return NewProperty(name, options...), nil
 }
 
 func (p *FrameworkProperty) Types() map[string]attr.Type {
 	return map[string]attr.Type{
-		"include_in_tags":  types.BoolType,
-		"max_length":       types.Int64Type,
-		"min_length":       types.Int64Type,
-		"required":         types.BoolType,
-		"tags_key_case":    types.StringType,
-		"tags_value_case":  types.StringType,
-		"validation_regex": types.StringType,
+		"allowed_value_descriptions": types.MapType{ElemType: types.StringType},
+		"allowed_values":             types.ListType{ElemType: types.StringType},
+		"default":                    types.StringType,
+		"derive_from":                types.StringType,
+		"include_in_tags":            types.BoolType,
+		"label_lookup":               types.StringType,
+		"max_length":                 types.Int64Type,
+		"min_length":                 types.Int64Type,
+		"order":                      types.Int64Type,
+		"required":                   types.BoolType,
+		"required_when":              types.MapType{ElemType: types.ListType{ElemType: types.StringType}},
+		"severity":                   types.MapType{ElemType: types.StringType},
+		"tag_lookup":                 types.StringType,
+		"tags_key_case":              types.StringType,
+		"tags_value_case":            types.StringType,
+		"validation_regex":           types.StringType,
 	}
 }
 
 func (p *FrameworkProperty) FromConfigProperty(cp *Property) FrameworkProperty {
 	fp := FrameworkProperty{
-		IncludeInTags:   types.BoolValue(cp.IncludeInTags),
-		MaxLength:       types.Int64Value(int64(cp.MaxLength)),
-		MinLength:       types.Int64Value(int64(cp.MinLength)),
-		Required:        types.BoolValue(cp.Required),
-		ValidationRegex: types.StringValue(cp.ValidationRegex),
+		AllowedValueDescriptions: types.MapNull(types.StringType),
+		AllowedValues:            types.ListNull(types.StringType),
+		Default:                  types.StringValue(cp.Default),
+		DeriveFrom:               types.StringValue(cp.DeriveFrom),
+		IncludeInTags:            types.BoolValue(cp.IncludeInTags),
+		LabelLookup:              types.StringValue(cp.LabelLookup),
+		MaxLength:                types.Int64Value(int64(cp.MaxLength)),
+		MinLength:                types.Int64Value(int64(cp.MinLength)),
+		Order:                    types.Int64Value(int64(cp.Order)),
+		Required:                 types.BoolValue(cp.Required),
+		RequiredWhen:             types.MapNull(types.ListType{ElemType: types.StringType}),
+		Severity:                 types.MapNull(types.StringType),
+		TagLookup:                types.StringValue(cp.TagLookup),
+		ValidationRegex:          types.StringValue(cp.ValidationRegex),
+	}
+	if len(cp.AllowedValues) > 0 {
+		values := make([]attr.Value, 0, len(cp.AllowedValues))
+		descriptions := map[string]attr.Value{}
+		for _, av := range cp.AllowedValues {
+			values = append(values, types.StringValue(av.Value))
+			if av.Description != "" {
+				descriptions[av.Value] = types.StringValue(av.Description)
+			}
+		}
+		fp.AllowedValues = types.ListValueMust(types.StringType, values)
+		fp.AllowedValueDescriptions = types.MapValueMust(types.StringType, descriptions)
+	}
+	if len(cp.RequiredWhen) > 0 {
+		conditions := make(map[string]attr.Value, len(cp.RequiredWhen))
+		for name, patterns := range cp.RequiredWhen {
+			values := make([]attr.Value, 0, len(patterns))
+			for _, pattern := range patterns {
+				values = append(values, types.StringValue(pattern))
+			}
+			conditions[name] = types.ListValueMust(types.StringType, values)
+		}
+		fp.RequiredWhen = types.MapValueMust(types.ListType{ElemType: types.StringType}, conditions)
+	}
+	if len(cp.Severities) > 0 {
+		severities := make(map[string]attr.Value, len(cp.Severities))
+		for check, severity := range cp.Severities {
+			severities[check] = types.StringValue(string(severity))
+		}
+		fp.Severity = types.MapValueMust(types.StringType, severities)
 	}
 	if cp.TagsKeyCase != nil {
 		fp.TagsKeyCase = types.StringValue(cp.TagsKeyCase.String())
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// FrameworkValidationRule describes a validation rule in the provider configuration.
+type FrameworkValidationRule struct {
+	Condition    types.String `tfsdk:"condition"`
+	ErrorMessage types.String `tfsdk:"error_message"`
+	Name         types.String `tfsdk:"name"`
+}
+
+func (r *FrameworkValidationRule) ToModel() ValidationRule {
+	return ValidationRule{
+		Name:         r.Name.ValueString(),
+		Condition:    r.Condition.ValueString(),
+		ErrorMessage: r.ErrorMessage.ValueString(),
+	}
+}
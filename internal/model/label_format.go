@@ -0,0 +1,104 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	ErrUnknownLabelFormat = errors.New("unknown label format")
+	ErrInvalidLabelFormat = errors.New("invalid label format")
+)
+
+// LabelFormat is a named label shape that can be reused by labels, like the descriptor formats of null-label. A format
+// is either delimited, built from properties and a delimiter, or templated.
+type LabelFormat struct {
+	Delimiter  *string
+	MaxLength  *int
+	Properties []string
+	Template   *string
+	Truncate   *bool
+}
+
+// Validate checks that the format is either delimited or templated and that its maximum length is not negative.
+func (f LabelFormat) Validate() error {
+	if f.Template != nil && (f.Delimiter != nil || len(f.Properties) > 0) {
+		return fmt.Errorf("%w: template conflicts with delimiter and properties", ErrInvalidLabelFormat)
+	}
+	if f.MaxLength != nil && *f.MaxLength < 0 {
+		return fmt.Errorf("%w: max_length must be at least 0", ErrInvalidLabelFormat)
+	}
+	return nil
+}
+
+// GetLabelFormats returns the label formats from the context.
+func (c *ProviderConfig) GetLabelFormats() map[string]LabelFormat {
+	return c.labelFormats
+}
+
+// GetLabelFormatNames returns the names of the label formats in the context, sorted alphabetically.
+func (c *ProviderConfig) GetLabelFormatNames() []string {
+	names := make([]string, 0, len(c.labelFormats))
+	for name := range c.labelFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetLabelFormat returns the label format with the given name.
+func (c *ProviderConfig) GetLabelFormat(name string) (LabelFormat, error) {
+	format, ok := c.labelFormats[name]
+	if !ok {
+		return LabelFormat{}, fmt.Errorf("%w: %q, valid formats are: %v", ErrUnknownLabelFormat, name, c.GetLabelFormatNames())
+	}
+	return format, nil
+}
+
+// GetFormattedLabel returns a label rendered with the named format and the values in the context, overridden by the
+// values passed into the function. Formats without a max_length are not limited and formats without truncate are
+// truncated, like labels of the label data source.
+func (c *ProviderConfig) GetFormattedLabel(name string, values map[string]string) (string, []error) {
+	format, err := c.GetLabelFormat(name)
+	if err != nil {
+		return "", []error{err}
+	}
+
+	maxLength := 0
+	if format.MaxLength != nil {
+		maxLength = *format.MaxLength
+	}
+	truncate := true
+	if format.Truncate != nil {
+		truncate = *format.Truncate
+	}
+
+	if format.Template != nil {
+		return c.GetTemplatedLabel(*format.Template, values, nil, maxLength, truncate)
+	}
+	return c.GetDelimitedLabel(format.Delimiter, format.Properties, format.Properties, values, nil, maxLength, truncate)
+}
+
+// validateLabelFormats validates every label format in the context.
+func (c *ProviderConfig) validateLabelFormats() error {
+	for _, name := range c.GetLabelFormatNames() {
+		if err := c.labelFormats[name].Validate(); err != nil {
+			return fmt.Errorf("label format %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// WithLabelFormats is a functional option for adding label formats to the context when creating a new provider config.
+// Formats are merged by name, so a later option replaces a format with the same name.
+func WithLabelFormats(formats map[string]LabelFormat) func(*ProviderConfig) {
+	return func(obj *ProviderConfig) {
+		if obj.labelFormats == nil {
+			obj.labelFormats = make(map[string]LabelFormat, len(formats))
+		}
+		for name, format := range formats {
+			obj.labelFormats[name] = format
+		}
+	}
+}
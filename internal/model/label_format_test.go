@@ -0,0 +1,78 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getLabelFormatProviderConfig(t *testing.T) *ProviderConfig {
+	t.Helper()
+	delimiter, template, maxLength, truncate := "_", "{{.baz}}/{{.foo}}", 5, false
+	formats := map[string]LabelFormat{
+		"default":   {},
+		"short":     {Delimiter: &delimiter, Properties: []string{"baz", "foo"}},
+		"templated": {Template: &template},
+		"limited":   {MaxLength: &maxLength, Truncate: &truncate},
+	}
+
+	properties := []Property{*NewProperty("foo", WithOrder(1)), *NewProperty("bar", WithOrder(2)), *NewProperty("baz", WithOrder(3))}
+	c, err := NewProviderConfig(properties, nil, map[string]string{"foo": "foo", "bar": "bar", "baz": "baz"}, WithLabelFormats(formats))
+	assert.NoError(t, err)
+	return c
+}
+
+func TestProviderConfigGetFormattedLabel(t *testing.T) {
+	c := getLabelFormatProviderConfig(t)
+
+	testCases := map[string]struct {
+		values   map[string]string
+		expected string
+	}{
+		"default":   {expected: "foo-bar-baz"},
+		"short":     {expected: "baz_foo"},
+		"templated": {values: map[string]string{"foo": "qux"}, expected: "baz/qux"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actual, errs := c.GetFormattedLabel(name, tc.values)
+			assert.Empty(t, errs)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestProviderConfigGetFormattedLabelErrors(t *testing.T) {
+	c := getLabelFormatProviderConfig(t)
+
+	_, errs := c.GetFormattedLabel("limited", nil)
+	assert.Len(t, errs, 1)
+	assert.True(t, errors.Is(errs[0], ErrLabelTooLong))
+
+	_, errs = c.GetFormattedLabel("missing", nil)
+	assert.Len(t, errs, 1)
+	assert.True(t, errors.Is(errs[0], ErrUnknownLabelFormat))
+	assert.Contains(t, errs[0].Error(), "[default limited short templated]")
+}
+
+func TestNewProviderConfigInvalidLabelFormat(t *testing.T) {
+	delimiter, template := "-", "{{.foo}}"
+	_, err := NewProviderConfig(nil, nil, nil, WithLabelFormats(map[string]LabelFormat{
+		"both": {Delimiter: &delimiter, Template: &template},
+	}))
+	assert.True(t, errors.Is(err, ErrInvalidLabelFormat))
+	assert.Contains(t, err.Error(), `label format "both"`)
+}
+
+func TestWithLabelFormatsMergesByName(t *testing.T) {
+	first, second := "{{.foo}}", "{{.bar}}"
+	c, err := NewProviderConfig(nil, nil, nil,
+		WithLabelFormats(map[string]LabelFormat{"a": {Template: &first}, "b": {Template: &first}}),
+		WithLabelFormats(map[string]LabelFormat{"b": {Template: &second}}),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, c.GetLabelFormatNames())
+	assert.Equal(t, &second, c.GetLabelFormats()["b"].Template)
+}
@@ -0,0 +1,183 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrUndeclaredProperty = errors.New("property is not declared")
+	ErrInvalidLengthRange = errors.New("minimum length is greater than maximum length")
+	ErrTagKeyCollision    = errors.New("tag key is used by more than one property")
+)
+
+const (
+	// LintSettingProperties marks a problem with the definition of a property.
+	LintSettingProperties = "properties"
+	// LintSettingPropertyOrder marks a problem with an entry of the property order.
+	LintSettingPropertyOrder = "property_order"
+	// LintSettingReplaceCharsRegex marks a problem with the regex used to redact labels.
+	LintSettingReplaceCharsRegex = "replace_chars_regex"
+	// LintSettingValues marks a problem with a value.
+	LintSettingValues = "values"
+)
+
+// LintError describes a problem with the configuration of a context, such as a property order entry that names no
+// property. Setting is the name of the setting with the problem and Key the property, value or entry in it, if any.
+type LintError struct {
+	Setting string
+	Key     string
+	Err     error
+}
+
+func (e *LintError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LintError) Unwrap() error {
+	return e.Err
+}
+
+// Lint checks the configuration of the context itself, independent of any label or tags created from it. It reports
+// regexes that do not compile, required_when conditions, property order entries and values that name no declared
+// property, properties whose minimum length is greater than their maximum length and properties whose tag keys are the
+// same after case conversion. Conditions, values and property order entries are only checked when the context declares
+// properties.
+func (c *ProviderConfig) Lint() []error {
+	errs := []error{}
+
+	if _, err := c.engine.getRegex(c.replaceCharsRegex); err != nil {
+		errs = append(errs, &LintError{
+			Setting: LintSettingReplaceCharsRegex,
+			Err:     fmt.Errorf("%w: %s for replace_chars_regex", ErrInvalidRegex, c.replaceCharsRegex),
+		})
+	}
+
+	for _, p := range c.properties {
+		errs = append(errs, c.lintProperty(p)...)
+	}
+
+	if len(c.properties) > 0 {
+		errs = append(errs, c.lintUndeclaredProperties()...)
+	}
+
+	return append(errs, c.lintTagKeys()...)
+}
+
+// lintProperty checks the definition of a single property.
+func (c *ProviderConfig) lintProperty(p Property) []error {
+	errs := []error{}
+
+	if p.ValidationRegex != "" {
+		if _, err := c.engine.getRegex(p.ValidationRegex); err != nil {
+			errs = append(errs, &LintError{Setting: LintSettingProperties, Key: p.Name, Err: invalidValidationRegexError(p.ValidationRegex, p.Name)})
+		}
+	}
+
+	for _, name := range p.getRequiredWhenProperties() {
+		for _, pattern := range p.RequiredWhen[name] {
+			if _, err := c.engine.getRegex(getRequiredWhenRegex(pattern)); err != nil {
+				errs = append(errs, &LintError{Setting: LintSettingProperties, Key: p.Name, Err: invalidRequiredWhenRegexError(pattern, p.Name)})
+			}
+		}
+	}
+
+	if p.MaxLength > 0 && p.MinLength > p.MaxLength {
+		errs = append(errs, &LintError{
+			Setting: LintSettingProperties,
+			Key:     p.Name,
+			Err:     fmt.Errorf("%w: %d is greater than %d for property %s", ErrInvalidLengthRange, p.MinLength, p.MaxLength, p.Name),
+		})
+	}
+
+	return errs
+}
+
+// lintUndeclaredProperties checks that the required_when conditions, property order entries and values name declared
+// properties. Values read from the environment are not checked.
+func (c *ProviderConfig) lintUndeclaredProperties() []error {
+	errs := []error{}
+
+	declared := map[string]bool{}
+	for _, p := range c.properties {
+		declared[p.Name] = true
+	}
+
+	for _, p := range c.properties {
+		for _, name := range p.getRequiredWhenProperties() {
+			if !declared[name] {
+				errs = append(errs, &LintError{
+					Setting: LintSettingProperties,
+					Key:     p.Name,
+					Err:     fmt.Errorf("%w: required_when condition %s of property %s", ErrUndeclaredProperty, name, p.Name),
+				})
+			}
+		}
+	}
+
+	for _, name := range c.propertyOrder {
+		if !declared[name] {
+			errs = append(errs, &LintError{
+				Setting: LintSettingPropertyOrder,
+				Key:     name,
+				Err:     fmt.Errorf("%w: property order entry %s", ErrUndeclaredProperty, name),
+			})
+		}
+	}
+
+	keys := make([]string, 0, len(c.values))
+	for k := range c.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		// The environment may hold values for other contexts, so only values set in the configuration are checked
+		if !declared[k] && c.valueSources[k] != ValueSourceEnvironment {
+			errs = append(errs, &LintError{
+				Setting: LintSettingValues,
+				Key:     k,
+				Err:     fmt.Errorf("%w: value for property %s", ErrUndeclaredProperty, k),
+			})
+		}
+	}
+
+	return errs
+}
+
+// lintTagKeys checks that no two properties included in tags have the same tag key after case conversion, since one
+// tag would silently replace the other.
+func (c *ProviderConfig) lintTagKeys() []error {
+	properties := map[string][]string{}
+	for _, p := range c.properties {
+		if !p.IncludeInTags {
+			continue
+		}
+		keyCase := c.tagsKeyCase
+		if p.TagsKeyCase != nil {
+			keyCase = *p.TagsKeyCase
+		}
+		key := keyCase.Apply(p.Name)
+		properties[key] = append(properties[key], p.Name)
+	}
+
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	errs := []error{}
+	for _, k := range keys {
+		if names := properties[k]; len(names) > 1 {
+			sort.Strings(names)
+			errs = append(errs, &LintError{
+				Setting: LintSettingProperties,
+				Key:     names[1],
+				Err:     fmt.Errorf("%w: %s for properties %s", ErrTagKeyCollision, k, strings.Join(names, ", ")),
+			})
+		}
+	}
+	return errs
+}
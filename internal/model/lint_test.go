@@ -0,0 +1,104 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cloudposse/terraform-provider-context/pkg/cases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderConfigLintValidConfig(t *testing.T) {
+	c := getDefaultProviderConfig(t, true)
+
+	assert.Empty(t, c.Lint())
+}
+
+func TestProviderConfigLint(t *testing.T) {
+	properties := []Property{
+		*NewProperty("namespace", WithValidationRegex("[")),
+		*NewProperty("stage", WithMinLength(5), WithMaxLength(3)),
+		*NewProperty("stage_name"),
+		*NewProperty("stageName"),
+	}
+	values := map[string]string{"namespace": "cp", "tenant": "core"}
+	c, err := NewProviderConfig(properties, []string{"namespace", "environment", "stage"}, values,
+		WithReplaceCharsRegex("(unclosed"), WithTagsKeyCase(cases.CamelCase))
+	assert.NoError(t, err)
+
+	errs := c.Lint()
+
+	expected := []struct {
+		setting string
+		key     string
+		err     error
+		message string
+	}{
+		{LintSettingReplaceCharsRegex, "", ErrInvalidRegex, "regex is invalid: (unclosed for replace_chars_regex"},
+		{LintSettingProperties, "namespace", ErrInvalidRegex, "regex is invalid: [ for property namespace"},
+		{LintSettingProperties, "stage", ErrInvalidLengthRange, "minimum length is greater than maximum length: 5 is greater than 3 for property stage"},
+		{LintSettingPropertyOrder, "environment", ErrUndeclaredProperty, "property is not declared: property order entry environment"},
+		{LintSettingValues, "tenant", ErrUndeclaredProperty, "property is not declared: value for property tenant"},
+		{LintSettingProperties, "stage_name", ErrTagKeyCollision, "tag key is used by more than one property: stageName for properties stageName, stage_name"},
+	}
+	assert.Len(t, errs, len(expected))
+	for i, e := range expected {
+		var lintErr *LintError
+		assert.True(t, errors.As(errs[i], &lintErr))
+		assert.Equal(t, e.setting, lintErr.Setting)
+		assert.Equal(t, e.key, lintErr.Key)
+		assert.ErrorIs(t, errs[i], e.err)
+		assert.Equal(t, e.message, errs[i].Error())
+	}
+}
+
+func TestProviderConfigLintSkipsEnvironmentValues(t *testing.T) {
+	values := map[string]string{"namespace": "cp", "tenant": "core", "region": "us-west-2"}
+	c, err := NewProviderConfig([]Property{*NewProperty("namespace")}, []string{}, values,
+		WithValueSources(map[string]string{"namespace": ValueSourceProvider, "tenant": ValueSourceProvider, "region": ValueSourceEnvironment}))
+	assert.NoError(t, err)
+
+	errs := c.Lint()
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "property is not declared: value for property tenant", errs[0].Error())
+}
+
+func TestProviderConfigLintWithoutProperties(t *testing.T) {
+	c, err := NewProviderConfig([]Property{}, []string{"namespace"}, map[string]string{"namespace": "cp"})
+	assert.NoError(t, err)
+
+	// There are no declared properties to check the property order and values against
+	assert.Empty(t, c.Lint())
+}
+
+func TestProviderConfigLintPropertyTagsKeyCase(t *testing.T) {
+	properties := []Property{
+		*NewProperty("stage_name", WithPropertyTagsKeyCase(cases.SnakeCase)),
+		*NewProperty("stageName", WithPropertyTagsKeyCase(cases.SnakeCase)),
+		*NewProperty("StageName", WithExcludeFromTags()),
+	}
+	c, err := NewProviderConfig(properties, []string{}, map[string]string{})
+	assert.NoError(t, err)
+
+	errs := c.Lint()
+
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrTagKeyCollision)
+}
+
+func TestProviderConfigLintRequiredWhen(t *testing.T) {
+	properties := []Property{
+		*NewProperty("namespace"),
+		*NewProperty("tenant", WithRequiredWhen(map[string][]string{"namespace": {"acme("}, "org": {"cp"}})),
+	}
+	c, err := NewProviderConfig(properties, []string{}, map[string]string{})
+	assert.NoError(t, err)
+
+	errs := c.Lint()
+
+	assert.Len(t, errs, 2)
+	assert.ErrorIs(t, errs[0], ErrInvalidRegex)
+	assert.Equal(t, "regex is invalid: acme( in required_when for property tenant", errs[0].Error())
+	assert.ErrorIs(t, errs[1], ErrUndeclaredProperty)
+	assert.Equal(t, "property is not declared: required_when condition org of property tenant", errs[1].Error())
+}
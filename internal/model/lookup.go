@@ -0,0 +1,131 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+var (
+	ErrUnknownLookup    = errors.New("unknown lookup")
+	ErrValueNotInLookup = errors.New("value is not in lookup")
+)
+
+// Lookup is a table that replaces the values of properties when they are rendered, such as abbreviations of regions
+// in labels while tags keep the full values. A value that is not in the table passes through unchanged, unless the
+// lookup is strict, in which case it is an error.
+type Lookup struct {
+	Values map[string]string
+	Strict bool
+}
+
+// WithLabelLookup sets the lookup that replaces the value of the property in labels.
+func WithLabelLookup(name string) func(*Property) {
+	return func(obj *Property) {
+		obj.LabelLookup = name
+	}
+}
+
+// WithTagLookup sets the lookup that replaces the value of the property in tags.
+func WithTagLookup(name string) func(*Property) {
+	return func(obj *Property) {
+		obj.TagLookup = name
+	}
+}
+
+// GetLookups returns the lookups from the context.
+func (c *ProviderConfig) GetLookups() map[string]Lookup {
+	return c.lookups
+}
+
+// GetLookupNames returns the names of the lookups in the context, sorted alphabetically.
+func (c *ProviderConfig) GetLookupNames() []string {
+	names := make([]string, 0, len(c.lookups))
+	for name := range c.lookups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetLookup returns the lookup with the given name from the context or, if the context has no lookup with that name,
+// the built-in lookup, such as aws_region_short.
+func (c *ProviderConfig) GetLookup(name string) (Lookup, error) {
+	if lookup, ok := c.lookups[name]; ok {
+		return lookup, nil
+	}
+	if lookup, ok := builtinLookups[name]; ok {
+		return lookup, nil
+	}
+	names := append(c.GetLookupNames(), GetBuiltinLookupNames()...)
+	sort.Strings(names)
+	return Lookup{}, fmt.Errorf("%w: %q, valid lookups are: %v", ErrUnknownLookup, name, slices.Compact(names))
+}
+
+// validateLookups checks that every lookup named by a property is in the context or built in.
+func (c *ProviderConfig) validateLookups() error {
+	for _, p := range c.properties {
+		for _, name := range []string{p.LabelLookup, p.TagLookup} {
+			if name == "" {
+				continue
+			}
+			if _, err := c.GetLookup(name); err != nil {
+				return fmt.Errorf("property %s: %w", p.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// getLabelValues returns the values with the label lookup of each property applied.
+func (c *ProviderConfig) getLabelValues(values map[string]string) (map[string]string, []error) {
+	return c.lookupValues(values, func(p *Property) string { return p.LabelLookup })
+}
+
+// getTagValues returns the values with the tag lookup of each property applied.
+func (c *ProviderConfig) getTagValues(values map[string]string) (map[string]string, []error) {
+	return c.lookupValues(values, func(p *Property) string { return p.TagLookup })
+}
+
+// lookupValues returns a copy of the values where the value of each property is replaced using the lookup returned by
+// getLookup. Empty values are left to the required check, so they are never looked up.
+func (c *ProviderConfig) lookupValues(values map[string]string, getLookup func(*Property) string) (map[string]string, []error) {
+	result := make(map[string]string, len(values))
+	for k, v := range values {
+		result[k] = v
+	}
+
+	errs := []error{}
+	for i := range c.properties {
+		p := &c.properties[i]
+		name := getLookup(p)
+		value := values[p.Name]
+		if name == "" || value == "" {
+			continue
+		}
+
+		// The lookups of the properties are checked when the context is created
+		lookup, _ := c.GetLookup(name)
+		if replacement, ok := lookup.Values[value]; ok {
+			result[p.Name] = replacement
+		} else if lookup.Strict {
+			err := fmt.Errorf("%w: %s for property %s in lookup %s", ErrValueNotInLookup, value, p.Name, name)
+			errs = append(errs, &PropertyError{Property: p.Name, Value: value, Err: err})
+		}
+	}
+	return result, errs
+}
+
+// WithLookups is a functional option for adding lookups to the context when creating a new provider config. Lookups
+// are merged by name, so a later option replaces a lookup with the same name.
+func WithLookups(lookups map[string]Lookup) func(*ProviderConfig) {
+	return func(obj *ProviderConfig) {
+		if obj.lookups == nil {
+			obj.lookups = make(map[string]Lookup, len(lookups))
+		}
+		for name, lookup := range lookups {
+			obj.lookups[name] = lookup
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getLookupProviderConfig(t *testing.T, strict bool) *ProviderConfig {
+	t.Helper()
+	properties := []Property{
+		*NewProperty("namespace", WithOrder(1)),
+		*NewProperty("region", WithOrder(2), WithLabelLookup("regions"), WithValidationRegex("^[a-z]+-[a-z]+-[0-9]$")),
+		*NewProperty("stage", WithOrder(3), WithLabelLookup("stages"), WithTagLookup("stages")),
+	}
+	c, err := NewProviderConfig(properties, []string{}, map[string]string{"namespace": "cp", "region": "us-west-2"},
+		WithLookups(map[string]Lookup{
+			"regions": {Values: map[string]string{"us-west-2": "uw2", "eu-central-1": "ec1"}, Strict: strict},
+			"stages":  {Values: map[string]string{"production": "prod"}},
+		}),
+	)
+	assert.NoError(t, err)
+	return c
+}
+
+func TestGetDelimitedLabelLookup(t *testing.T) {
+	c := getLookupProviderConfig(t, false)
+
+	label, errs := c.GetDelimitedLabel(nil, nil, nil, map[string]string{"stage": "production"}, nil, 0, true)
+	assert.Empty(t, errs)
+	assert.Equal(t, "cp-uw2-prod", label)
+
+	// A value that is not in the lookup passes through
+	label, errs = c.GetDelimitedLabel(nil, nil, nil, map[string]string{"region": "ap-south-1", "stage": "dev"}, nil, 0, true)
+	assert.Empty(t, errs)
+	assert.Equal(t, "cp-ap-south-1-dev", label)
+}
+
+func TestGetTemplatedLabelLookup(t *testing.T) {
+	c := getLookupProviderConfig(t, false)
+
+	label, errs := c.GetTemplatedLabel("{{.namespace}}/{{.region}}/{{.stage}}", map[string]string{"stage": "production"}, nil, 0, true)
+	assert.Empty(t, errs)
+	assert.Equal(t, "cp/uw2/prod", label)
+}
+
+func TestGetTagsLookup(t *testing.T) {
+	c := getLookupProviderConfig(t, false)
+
+	// The region has no tag lookup, so its tag keeps the full value
+	tags, errs := c.GetTags(map[string]string{"stage": "production"}, nil, nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]string{"Namespace": "cp", "Region": "us-west-2", "Stage": "prod"}, tags)
+}
+
+func TestLookupStrict(t *testing.T) {
+	c := getLookupProviderConfig(t, true)
+
+	label, errs := c.GetDelimitedLabel(nil, nil, nil, map[string]string{"region": "ap-south-1"}, nil, 0, true)
+	assert.Empty(t, label)
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrValueNotInLookup)
+	assert.Equal(t, "value is not in lookup: ap-south-1 for property region in lookup regions", errs[0].Error())
+	var propertyErr *PropertyError
+	assert.ErrorAs(t, errs[0], &propertyErr)
+	assert.Equal(t, "region", propertyErr.Property)
+
+	// Tags do not use the lookup of the region, so they are not affected
+	_, errs = c.GetTags(map[string]string{"region": "ap-south-1"}, nil, nil)
+	assert.Empty(t, errs)
+}
+
+func TestLookupValidatesValueBeforeLookup(t *testing.T) {
+	c := getLookupProviderConfig(t, false)
+
+	// The validation regex applies to the full value, not the abbreviation
+	_, errs := c.GetDelimitedLabel(nil, nil, nil, map[string]string{"region": "uw2"}, nil, 0, true)
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrRegexMismatch)
+}
+
+func TestExplainDelimitedLabelLookup(t *testing.T) {
+	c := getLookupProviderConfig(t, false)
+
+	_, explanation, errs := c.ExplainDelimitedLabel(nil, nil, nil, nil, nil, 0, true)
+	assert.Empty(t, errs)
+	assert.Equal(t, "uw2", getExplainedProperty(t, explanation, "region").Value)
+}
+
+func TestNewProviderConfigUnknownLookup(t *testing.T) {
+	properties := []Property{*NewProperty("region", WithTagLookup("regions"))}
+	_, err := NewProviderConfig(properties, []string{}, map[string]string{})
+	assert.ErrorIs(t, err, ErrUnknownLookup)
+	assert.ErrorContains(t, err, `property region: unknown lookup: "regions"`)
+}
+
+func TestWithLookupsMergesByName(t *testing.T) {
+	c, err := NewProviderConfig([]Property{}, []string{}, map[string]string{},
+		WithLookups(map[string]Lookup{"regions": {Values: map[string]string{"us-west-2": "uw2"}}, "stages": {}}),
+		WithLookups(map[string]Lookup{"regions": {Values: map[string]string{"us-east-1": "ue1"}, Strict: true}}),
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"regions", "stages"}, c.GetLookupNames())
+	assert.Equal(t, Lookup{Values: map[string]string{"us-east-1": "ue1"}, Strict: true}, c.GetLookups()["regions"])
+}
@@ -0,0 +1,483 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"text/template/parse"
+	"unicode"
+
+	"github.com/cloudposse/terraform-provider-context/pkg/cases"
+	"github.com/cloudposse/terraform-provider-context/pkg/slice"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	ErrInvalidNullLabelContext = errors.New("invalid null-label context")
+	ErrUnsupportedFormatVerb   = errors.New("unsupported format verb")
+	ErrFormatLabelCount        = errors.New("number of labels does not match the format")
+)
+
+// ValueSourceNullLabelContext marks a value read from a null-label context object.
+const ValueSourceNullLabelContext = "null_label_context"
+
+// NullLabelAttributes is the label of null-label that holds the attributes, joined with the delimiter.
+const NullLabelAttributes = "attributes"
+
+// nullLabelDefaultRegexReplaceChars is the regex null-label uses when regex_replace_chars is unset.
+const nullLabelDefaultRegexReplaceChars = "[^-a-zA-Z0-9]"
+
+// nullLabelNoReplaceChars is a regex that matches no characters, to stop null-label from replacing any.
+const nullLabelNoReplaceChars = `[^\s\S]`
+
+// nullLabelDefaultOrder is the label order null-label uses when label_order is empty.
+var nullLabelDefaultOrder = []string{"namespace", "tenant", "environment", "stage", "name", NullLabelAttributes}
+
+// NullLabelContext is the context object of terraform-null-label, as passed around by context.tf.
+type NullLabelContext struct {
+	AdditionalTagMap  map[string]string                    `json:"additional_tag_map" tfsdk:"additional_tag_map"`
+	Attributes        []string                             `json:"attributes" tfsdk:"attributes"`
+	Delimiter         *string                              `json:"delimiter" tfsdk:"delimiter"`
+	DescriptorFormats map[string]NullLabelDescriptorFormat `json:"descriptor_formats" tfsdk:"descriptor_formats"`
+	Enabled           *bool                                `json:"enabled" tfsdk:"enabled"`
+	Environment       *string                              `json:"environment" tfsdk:"environment"`
+	IDLengthLimit     *int                                 `json:"id_length_limit" tfsdk:"id_length_limit"`
+	LabelKeyCase      *string                              `json:"label_key_case" tfsdk:"label_key_case"`
+	LabelOrder        []string                             `json:"label_order" tfsdk:"label_order"`
+	LabelValueCase    *string                              `json:"label_value_case" tfsdk:"label_value_case"`
+	LabelsAsTags      []string                             `json:"labels_as_tags" tfsdk:"labels_as_tags"`
+	Name              *string                              `json:"name" tfsdk:"name"`
+	Namespace         *string                              `json:"namespace" tfsdk:"namespace"`
+	RegexReplaceChars *string                              `json:"regex_replace_chars" tfsdk:"regex_replace_chars"`
+	Stage             *string                              `json:"stage" tfsdk:"stage"`
+	Tags              map[string]string                    `json:"tags" tfsdk:"tags"`
+	Tenant            *string                              `json:"tenant" tfsdk:"tenant"`
+}
+
+// NullLabelContextAttrTypes returns the framework types of a NullLabelContext.
+func NullLabelContextAttrTypes() map[string]attr.Type {
+	stringMap := types.MapType{ElemType: types.StringType}
+	stringList := types.ListType{ElemType: types.StringType}
+	return map[string]attr.Type{
+		"additional_tag_map": stringMap,
+		"attributes":         stringList,
+		"delimiter":          types.StringType,
+		"descriptor_formats": types.MapType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+			"format": types.StringType,
+			"labels": stringList,
+		}}},
+		"enabled":             types.BoolType,
+		"environment":         types.StringType,
+		"id_length_limit":     types.NumberType,
+		"label_key_case":      types.StringType,
+		"label_order":         stringList,
+		"label_value_case":    types.StringType,
+		"labels_as_tags":      stringList,
+		"name":                types.StringType,
+		"namespace":           types.StringType,
+		"regex_replace_chars": types.StringType,
+		"stage":               types.StringType,
+		"tags":                stringMap,
+		"tenant":              types.StringType,
+	}
+}
+
+// NullLabelDescriptorFormat is an entry of the descriptor_formats of null-label, e.g. {format = "%v-%v", labels =
+// ["tenant", "stage"]}.
+type NullLabelDescriptorFormat struct {
+	Format string   `json:"format" tfsdk:"format"`
+	Labels []string `json:"labels" tfsdk:"labels"`
+}
+
+// NullLabelContextWarning describes a field of a null-label context that could not be carried over.
+type NullLabelContextWarning struct {
+	Field   string
+	Message string
+}
+
+// ParseNullLabelContext parses a null-label context object encoded as JSON. Keys that are not part of the null-label
+// context are returned as warnings rather than errors, so contexts from newer null-label versions still load.
+func ParseNullLabelContext(data []byte) (*NullLabelContext, []NullLabelContextWarning, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidNullLabelContext, err)
+	}
+
+	warnings := []NullLabelContextWarning{}
+	for _, key := range sortedRawKeys(raw) {
+		if !slice.Contains(nullLabelContextKeys, key) {
+			warnings = append(warnings, NullLabelContextWarning{Field: key, Message: "is not a null-label context field and is ignored"})
+		}
+	}
+
+	var c NullLabelContext
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidNullLabelContext, err)
+	}
+
+	return &c, warnings, nil
+}
+
+// nullLabelContextKeys are the fields of a null-label context object.
+var nullLabelContextKeys = []string{
+	"additional_tag_map", "attributes", "delimiter", "descriptor_formats", "enabled", "environment", "id_length_limit",
+	"label_key_case", "label_order", "label_value_case", "labels_as_tags", "name", "namespace", "regex_replace_chars",
+	"stage", "tags", "tenant",
+}
+
+// ToContextFile converts the null-label context to the settings of a context file, so it can be layered into the
+// provider configuration like one. Fields without an equivalent setting are returned as warnings.
+//
+// null-label applies regex_replace_chars and label_value_case to each label before it builds the id, while the provider
+// applies replace_chars_regex to the whole label and has no label case. The labels are therefore normalized here, with
+// the null-label defaults when the fields are unset, so the imported values render the same id as null-label.
+func (c *NullLabelContext) ToContextFile() (*ContextFile, []NullLabelContextWarning) {
+	cf := &ContextFile{
+		Delimiter:    c.Delimiter,
+		Enabled:      c.Enabled,
+		LabelFormats: map[string]LabelFormat{},
+	}
+	warnings := c.getUnsupportedWarnings()
+
+	cf.PropertyOrder = c.LabelOrder
+	if len(cf.PropertyOrder) == 0 {
+		cf.PropertyOrder = nullLabelDefaultOrder
+	}
+	cf.Properties = c.getProperties(cf.PropertyOrder)
+
+	var warning *NullLabelContextWarning
+	if cf.TagsKeyCase, warning = getNullLabelCase("label_key_case", c.LabelKeyCase, cases.TitleCase); warning != nil {
+		warnings = append(warnings, *warning)
+	}
+	if cf.TagsValueCase, warning = getNullLabelCase("label_value_case", c.LabelValueCase, cases.LowerCase); warning != nil {
+		warnings = append(warnings, *warning)
+	}
+
+	regex, err := c.getReplaceCharsRegex()
+	if err != nil {
+		warnings = append(warnings, NullLabelContextWarning{Field: "regex_replace_chars", Message: err.Error()})
+	}
+	cf.Values = c.getValues(func(value string) string {
+		if regex != nil {
+			value = regex.ReplaceAllString(value, "")
+		}
+		return applyNullLabelCase(*cf.TagsValueCase, value)
+	})
+
+	for _, name := range sortedFormatNames(c.DescriptorFormats) {
+		template, err := c.DescriptorFormats[name].ToTemplate()
+		if err != nil {
+			warnings = append(warnings, NullLabelContextWarning{Field: "descriptor_formats." + name, Message: err.Error()})
+			continue
+		}
+		cf.LabelFormats[name] = LabelFormat{Template: &template}
+	}
+
+	return cf, warnings
+}
+
+// getReplaceCharsRegex compiles regex_replace_chars, or the null-label default when it is unset.
+func (c *NullLabelContext) getReplaceCharsRegex() (*regexp.Regexp, error) {
+	pattern := nullLabelDefaultRegexReplaceChars
+	if c.RegexReplaceChars != nil {
+		pattern = strings.TrimSuffix(strings.TrimPrefix(*c.RegexReplaceChars, "/"), "/")
+	}
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("is not a valid regex and is ignored: %w", err)
+	}
+	return regex, nil
+}
+
+// getValues returns the labels of the context as values, each normalized by the given function. Like null-label, empty
+// and duplicate attributes are dropped after normalizing and the rest are joined with the delimiter.
+func (c *NullLabelContext) getValues(normalize func(string) string) map[string]string {
+	values := map[string]string{}
+	for name, value := range map[string]*string{
+		"environment": c.Environment,
+		"name":        c.Name,
+		"namespace":   c.Namespace,
+		"stage":       c.Stage,
+		"tenant":      c.Tenant,
+	} {
+		if value != nil {
+			values[name] = normalize(*value)
+		}
+	}
+
+	attributes := []string{}
+	for _, attribute := range c.Attributes {
+		if attribute = normalize(attribute); attribute != "" && !slice.Contains(attributes, attribute) {
+			attributes = append(attributes, attribute)
+		}
+	}
+	if len(attributes) > 0 {
+		delimiter := "-"
+		if c.Delimiter != nil {
+			delimiter = *c.Delimiter
+		}
+		values[NullLabelAttributes] = strings.Join(attributes, delimiter)
+	}
+	return values
+}
+
+// getProperties returns a property for each null-label label, ordered by the label order. Labels missing from
+// labels_as_tags are excluded from tags, unless it is unset or contains "default".
+func (c *NullLabelContext) getProperties(order []string) []Property {
+	allTags := len(c.LabelsAsTags) == 0 || slice.Contains(c.LabelsAsTags, "default") || slice.Contains(c.LabelsAsTags, "unset")
+
+	properties := []Property{}
+	for _, name := range nullLabelDefaultOrder {
+		options := []PropertyOption{}
+		if position := slices.Index(order, name); position >= 0 {
+			options = append(options, WithOrder(position+1))
+		} else {
+			options = append(options, WithOrder(len(order)+1))
+		}
+		if !allTags && !slice.Contains(c.LabelsAsTags, name) {
+			options = append(options, WithExcludeFromTags())
+		}
+		properties = append(properties, *NewProperty(name, options...))
+	}
+
+	sort.SliceStable(properties, func(i, j int) bool {
+		return properties[i].Name < properties[j].Name
+	})
+	return properties
+}
+
+// getUnsupportedWarnings returns a warning for every field that is set but has no equivalent in the provider.
+func (c *NullLabelContext) getUnsupportedWarnings() []NullLabelContextWarning {
+	warnings := []NullLabelContextWarning{}
+	if len(c.Tags) > 0 {
+		warnings = append(warnings, NullLabelContextWarning{Field: "tags", Message: "is not supported, the tags are ignored"})
+	}
+	if len(c.AdditionalTagMap) > 0 {
+		warnings = append(warnings, NullLabelContextWarning{Field: "additional_tag_map", Message: "is not supported, the additional tags are ignored"})
+	}
+	if c.IDLengthLimit != nil && *c.IDLengthLimit > 0 {
+		warnings = append(warnings, NullLabelContextWarning{Field: "id_length_limit", Message: "is not supported, set max_length on each label or label format instead"})
+	}
+	for _, name := range c.LabelOrder {
+		if !slice.Contains(nullLabelDefaultOrder, name) {
+			warnings = append(warnings, NullLabelContextWarning{Field: "label_order", Message: fmt.Sprintf("contains %q, which is not a null-label label", name)})
+		}
+	}
+	return warnings
+}
+
+// ToTemplate converts the printf style format of the descriptor to a label template, using the labels in order, e.g.
+// "%v-%v" with ["tenant", "stage"] becomes "{{.tenant}}-{{.stage}}".
+func (f NullLabelDescriptorFormat) ToTemplate() (string, error) {
+	var template strings.Builder
+	used := 0
+	for i := 0; i < len(f.Format); i++ {
+		if f.Format[i] != '%' {
+			template.WriteByte(f.Format[i])
+			continue
+		}
+		if i+1 >= len(f.Format) {
+			return "", fmt.Errorf("%w: format ends with %%", ErrUnsupportedFormatVerb)
+		}
+		i++
+		switch verb := f.Format[i]; verb {
+		case '%':
+			template.WriteByte('%')
+		case 'v', 's':
+			if used >= len(f.Labels) {
+				return "", fmt.Errorf("%w: %q has more verbs than the %d labels", ErrFormatLabelCount, f.Format, len(f.Labels))
+			}
+			fmt.Fprintf(&template, "{{.%s}}", f.Labels[used])
+			used++
+		default:
+			return "", fmt.Errorf("%w: %%%c in %q, only %%v, %%s and %%%% are supported", ErrUnsupportedFormatVerb, verb, f.Format)
+		}
+	}
+	if used != len(f.Labels) {
+		return "", fmt.Errorf("%w: %q uses %d of the %d labels", ErrFormatLabelCount, f.Format, used, len(f.Labels))
+	}
+	return template.String(), nil
+}
+
+// getNullLabelCase converts a null-label case name, or returns the null-label default when it is unset or unknown.
+// null-label also accepts "none" for values.
+func getNullLabelCase(field string, name *string, defaultCase cases.Case) (*cases.Case, *NullLabelContextWarning) {
+	if name == nil || *name == "" {
+		return &defaultCase, nil
+	}
+	c, err := cases.FromString(*name)
+	if err != nil {
+		return &defaultCase, &NullLabelContextWarning{Field: field, Message: fmt.Sprintf("has unknown case %q, using %q", *name, defaultCase)}
+	}
+	return &c, nil
+}
+
+// applyNullLabelCase applies a label_value_case to a label the way null-label does. Unlike the title case of the
+// provider, which removes separators, null-label uses title(lower(value)) and keeps them.
+func applyNullLabelCase(c cases.Case, value string) string {
+	if c != cases.TitleCase {
+		return c.Apply(value)
+	}
+	title := []rune(strings.ToLower(value))
+	for i, r := range title {
+		if i == 0 || !unicode.IsLetter(title[i-1]) && !unicode.IsDigit(title[i-1]) && title[i-1] != '_' {
+			title[i] = unicode.ToTitle(r)
+		}
+	}
+	return string(title)
+}
+
+func sortedRawKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedFormatNames(m map[string]NullLabelDescriptorFormat) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ToNullLabelContext returns the context as a null-label context object, so it can be passed to modules that still
+// take the context input of context.tf. Properties that are not null-label labels, and label formats that cannot be
+// written as a printf style format of null-label labels, have no equivalent and are left out, as does tags_value_case.
+func (c *ProviderConfig) ToNullLabelContext() NullLabelContext {
+	enabled := c.enabled
+	delimiter := c.delimiter
+	nc := NullLabelContext{
+		AdditionalTagMap:  map[string]string{},
+		Attributes:        []string{},
+		Delimiter:         &delimiter,
+		DescriptorFormats: map[string]NullLabelDescriptorFormat{},
+		Enabled:           &enabled,
+		LabelOrder:        []string{},
+		LabelsAsTags:      []string{},
+		Tags:              map[string]string{},
+	}
+
+	for name, target := range map[string]**string{
+		"environment": &nc.Environment,
+		"name":        &nc.Name,
+		"namespace":   &nc.Namespace,
+		"stage":       &nc.Stage,
+		"tenant":      &nc.Tenant,
+	} {
+		if value, ok := c.values[name]; ok {
+			*target = &value
+		}
+	}
+	if attributes := c.values[NullLabelAttributes]; attributes != "" && c.delimiter != "" {
+		nc.Attributes = strings.Split(attributes, c.delimiter)
+	} else if attributes != "" {
+		nc.Attributes = []string{attributes}
+	}
+
+	// null-label falls back to its own regex when regex_replace_chars is null, so a regex that matches nothing is
+	// emitted when the provider does not replace characters.
+	regex := "/" + nullLabelNoReplaceChars + "/"
+	if c.replaceCharsRegex != "" {
+		regex = "/" + c.replaceCharsRegex + "/"
+	}
+	nc.RegexReplaceChars = &regex
+
+	// The provider has no label case, labels keep the case of their values. null-label also applies label_value_case to
+	// tag values, so tags_value_case has no equivalent.
+	valueCase := cases.None.String()
+	nc.LabelKeyCase = getNullLabelCaseName(c.tagsKeyCase, cases.LowerCase, cases.TitleCase, cases.UpperCase)
+	nc.LabelValueCase = &valueCase
+
+	for _, name := range c.propertyOrder {
+		if slice.Contains(nullLabelDefaultOrder, name) {
+			nc.LabelOrder = append(nc.LabelOrder, name)
+		}
+	}
+	for _, p := range c.properties {
+		if p.IncludeInTags && slice.Contains(nullLabelDefaultOrder, p.Name) {
+			nc.LabelsAsTags = append(nc.LabelsAsTags, p.Name)
+		}
+	}
+	sort.Strings(nc.LabelsAsTags)
+
+	for _, name := range c.GetLabelFormatNames() {
+		if format, ok := c.getDescriptorFormat(c.labelFormats[name]); ok {
+			nc.DescriptorFormats[name] = format
+		}
+	}
+
+	return nc
+}
+
+// getDescriptorFormat converts a label format to a null-label descriptor format. Templates can only be converted when
+// they are plain text and null-label labels, e.g. "{{.tenant}}-{{.stage}}".
+func (c *ProviderConfig) getDescriptorFormat(format LabelFormat) (NullLabelDescriptorFormat, bool) {
+	if format.Template == nil {
+		labels := c.GetMergedPropertyOrder(format.Properties)
+		for _, label := range labels {
+			if !slice.Contains(nullLabelDefaultOrder, label) {
+				return NullLabelDescriptorFormat{}, false
+			}
+		}
+		verbs := make([]string, len(labels))
+		for i := range verbs {
+			verbs[i] = "%v"
+		}
+		delimiter := strings.ReplaceAll(c.GetMergedDelimiter(format.Delimiter), "%", "%%")
+		return NullLabelDescriptorFormat{Format: strings.Join(verbs, delimiter), Labels: labels}, true
+	}
+
+	tree, err := parse.Parse("format", *format.Template, "", "")
+	if err != nil {
+		return NullLabelDescriptorFormat{}, false
+	}
+
+	descriptor := NullLabelDescriptorFormat{Labels: []string{}}
+	for _, node := range tree["format"].Root.Nodes {
+		switch n := node.(type) {
+		case *parse.TextNode:
+			descriptor.Format += strings.ReplaceAll(string(n.Text), "%", "%%")
+		case *parse.ActionNode:
+			label, ok := getActionLabel(n)
+			if !ok {
+				return NullLabelDescriptorFormat{}, false
+			}
+			descriptor.Format += "%v"
+			descriptor.Labels = append(descriptor.Labels, label)
+		default:
+			return NullLabelDescriptorFormat{}, false
+		}
+	}
+	return descriptor, true
+}
+
+// getActionLabel returns the null-label label of a template action that only prints a field, e.g. {{.stage}}.
+func getActionLabel(n *parse.ActionNode) (string, bool) {
+	if len(n.Pipe.Decl) > 0 || len(n.Pipe.Cmds) != 1 || len(n.Pipe.Cmds[0].Args) != 1 {
+		return "", false
+	}
+	field, ok := n.Pipe.Cmds[0].Args[0].(*parse.FieldNode)
+	if !ok || len(field.Ident) != 1 || !slice.Contains(nullLabelDefaultOrder, field.Ident[0]) {
+		return "", false
+	}
+	return field.Ident[0], true
+}
+
+// getNullLabelCaseName returns the name of the case if null-label supports it, or nil to use the null-label default.
+func getNullLabelCaseName(c cases.Case, supported ...cases.Case) *string {
+	if !slices.Contains(supported, c) {
+		return nil
+	}
+	name := c.String()
+	return &name
+}
@@ -0,0 +1,247 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudposse/terraform-provider-context/pkg/cases"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testNullLabelContext = `{
+  "enabled": true,
+  "namespace": "cp",
+  "tenant": "core",
+  "environment": "ue1",
+  "stage": "prod",
+  "name": "app",
+  "delimiter": "-",
+  "attributes": ["blue", "1"],
+  "tags": {"Owner": "platform"},
+  "additional_tag_map": {},
+  "regex_replace_chars": "/[^-a-zA-Z0-9]/",
+  "label_order": ["namespace", "environment", "stage", "name", "attributes"],
+  "id_length_limit": 0,
+  "label_key_case": "title",
+  "label_value_case": "lower",
+  "descriptor_formats": {
+    "stack": {"format": "%v-%v-%v", "labels": ["tenant", "environment", "stage"]},
+    "broken": {"format": "%d", "labels": ["name"]}
+  },
+  "labels_as_tags": ["namespace", "name"],
+  "extra": "ignored"
+}`
+
+func TestParseNullLabelContext(t *testing.T) {
+	c, warnings, err := ParseNullLabelContext([]byte(testNullLabelContext))
+	assert.NoError(t, err)
+	assert.Equal(t, []NullLabelContextWarning{{Field: "extra", Message: "is not a null-label context field and is ignored"}}, warnings)
+	assert.Equal(t, "cp", *c.Namespace)
+	assert.Equal(t, 0, *c.IDLengthLimit)
+	assert.Equal(t, NullLabelDescriptorFormat{Format: "%v-%v-%v", Labels: []string{"tenant", "environment", "stage"}}, c.DescriptorFormats["stack"])
+
+	_, _, err = ParseNullLabelContext([]byte(`{"namespace": 1}`))
+	assert.True(t, errors.Is(err, ErrInvalidNullLabelContext))
+}
+
+func TestNullLabelContextToContextFile(t *testing.T) {
+	c, _, err := ParseNullLabelContext([]byte(testNullLabelContext))
+	assert.NoError(t, err)
+
+	cf, warnings := c.ToContextFile()
+
+	assert.Equal(t, []NullLabelContextWarning{
+		{Field: "tags", Message: "is not supported, the tags are ignored"},
+		{Field: "descriptor_formats.broken", Message: `unsupported format verb: %d in "%d", only %v, %s and %% are supported`},
+	}, warnings)
+
+	assert.Equal(t, map[string]string{
+		"namespace": "cp", "tenant": "core", "environment": "ue1", "stage": "prod", "name": "app", "attributes": "blue-1",
+	}, cf.Values)
+	assert.Equal(t, []string{"namespace", "environment", "stage", "name", "attributes"}, cf.PropertyOrder)
+	assert.Nil(t, cf.ReplaceCharsRegex)
+	assert.Equal(t, cases.TitleCase, *cf.TagsKeyCase)
+	assert.Equal(t, cases.LowerCase, *cf.TagsValueCase)
+	assert.Equal(t, "{{.tenant}}-{{.environment}}-{{.stage}}", *cf.LabelFormats["stack"].Template)
+	assert.NotContains(t, cf.LabelFormats, "broken")
+
+	names := []string{}
+	tags := map[string]bool{}
+	for _, p := range cf.Properties {
+		names = append(names, p.Name)
+		tags[p.Name] = p.IncludeInTags
+	}
+	assert.Equal(t, []string{"attributes", "environment", "name", "namespace", "stage", "tenant"}, names)
+	assert.Equal(t, map[string]bool{"attributes": false, "environment": false, "name": true, "namespace": true, "stage": false, "tenant": false}, tags)
+
+	pc, err := NewProviderConfig(cf.Properties, cf.PropertyOrder, cf.Values, cf.Options()...)
+	assert.NoError(t, err)
+	label, errs := pc.GetDelimitedLabel(nil, nil, nil, nil, nil, 0, false)
+	assert.Empty(t, errs)
+	assert.Equal(t, "cp-ue1-prod-app-blue-1", label)
+}
+
+func TestNullLabelContextDefaults(t *testing.T) {
+	c, warnings, err := ParseNullLabelContext([]byte(`{"namespace": "cp", "name": "app", "labels_as_tags": ["unset"], "id_length_limit": 32, "label_key_case": "kebab"}`))
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	cf, warnings := c.ToContextFile()
+	assert.Equal(t, []NullLabelContextWarning{
+		{Field: "id_length_limit", Message: "is not supported, set max_length on each label or label format instead"},
+		{Field: "label_key_case", Message: `has unknown case "kebab", using "title"`},
+	}, warnings)
+	assert.Equal(t, nullLabelDefaultOrder, cf.PropertyOrder)
+	assert.Equal(t, cases.TitleCase, *cf.TagsKeyCase)
+	assert.Equal(t, cases.LowerCase, *cf.TagsValueCase)
+	for _, p := range cf.Properties {
+		assert.True(t, p.IncludeInTags, p.Name)
+	}
+}
+
+func TestNullLabelContextNormalizesValues(t *testing.T) {
+	testCases := map[string]struct {
+		context  string
+		expected map[string]string
+		label    string
+		warnings []NullLabelContextWarning
+	}{
+		"defaults": {
+			context:  `{"namespace": "CP", "name": "My_App", "attributes": ["Blue", "blue", "@"]}`,
+			expected: map[string]string{"namespace": "cp", "name": "myapp", "attributes": "blue"},
+			label:    "cp-myapp-blue",
+		},
+		"title": {
+			context:  `{"namespace": "cp", "name": "my-APP", "label_value_case": "title", "regex_replace_chars": "/[^-a-zA-Z]/"}`,
+			expected: map[string]string{"namespace": "Cp", "name": "My-App"},
+			label:    "Cp-My-App",
+		},
+		"delimiter kept": {
+			context:  `{"namespace": "cp", "name": "my_app", "delimiter": "_", "label_value_case": "none", "attributes": ["a_b"]}`,
+			expected: map[string]string{"namespace": "cp", "name": "myapp", "attributes": "ab"},
+			label:    "cp_myapp_ab",
+		},
+		"invalid regex": {
+			context:  `{"name": "My_App", "regex_replace_chars": "/[/"}`,
+			expected: map[string]string{"name": "my_app"},
+			label:    "my_app",
+			warnings: []NullLabelContextWarning{{Field: "regex_replace_chars", Message: "is not a valid regex and is ignored: error parsing regexp: missing closing ]: `[`"}},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c, _, err := ParseNullLabelContext([]byte(tc.context))
+			assert.NoError(t, err)
+
+			cf, warnings := c.ToContextFile()
+			if tc.warnings == nil {
+				assert.Empty(t, warnings)
+			} else {
+				assert.Equal(t, tc.warnings, warnings)
+			}
+			assert.Equal(t, tc.expected, cf.Values)
+
+			pc, err := NewProviderConfig(cf.Properties, cf.PropertyOrder, cf.Values, cf.Options()...)
+			assert.NoError(t, err)
+			label, errs := pc.GetDelimitedLabel(nil, nil, nil, nil, nil, 0, false)
+			assert.Empty(t, errs)
+			assert.Equal(t, tc.label, label)
+		})
+	}
+}
+
+func TestNullLabelDescriptorFormatToTemplate(t *testing.T) {
+	testCases := map[string]struct {
+		format   NullLabelDescriptorFormat
+		expected string
+		err      error
+	}{
+		"verbs":          {format: NullLabelDescriptorFormat{Format: "%v/%s", Labels: []string{"tenant", "stage"}}, expected: "{{.tenant}}/{{.stage}}"},
+		"escaped":        {format: NullLabelDescriptorFormat{Format: "100%%-%v", Labels: []string{"name"}}, expected: "100%-{{.name}}"},
+		"too few labels": {format: NullLabelDescriptorFormat{Format: "%v-%v", Labels: []string{"name"}}, err: ErrFormatLabelCount},
+		"unused labels":  {format: NullLabelDescriptorFormat{Format: "%v", Labels: []string{"name", "stage"}}, err: ErrFormatLabelCount},
+		"trailing %":     {format: NullLabelDescriptorFormat{Format: "%v%", Labels: []string{"name"}}, err: ErrUnsupportedFormatVerb},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := tc.format.ToTemplate()
+			if tc.err != nil {
+				assert.True(t, errors.Is(err, tc.err), err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestProviderConfigToNullLabelContext(t *testing.T) {
+	template, unsupported, delimiter := "{{.tenant}}/{{.stage}}", "{{if .stage}}{{.stage}}{{end}}", "_"
+	properties := []Property{
+		*NewProperty("namespace", WithOrder(1)),
+		*NewProperty("stage", WithOrder(2)),
+		*NewProperty("name", WithOrder(3), WithExcludeFromTags()),
+		*NewProperty("region", WithOrder(4)),
+		*NewProperty(NullLabelAttributes, WithOrder(5)),
+	}
+	values := map[string]string{"namespace": "cp", "stage": "prod", "name": "app", "region": "us-east-1", NullLabelAttributes: "blue-1"}
+	c, err := NewProviderConfig(properties, nil, values,
+		WithReplaceCharsRegex("[^a-z]"),
+		WithTagsKeyCase(cases.SnakeCase),
+		WithTagsValueCase(cases.UpperCase),
+		WithLabelFormats(map[string]LabelFormat{
+			"stack":       {Template: &template},
+			"short":       {Delimiter: &delimiter, Properties: []string{"namespace", "name"}},
+			"conditional": {Template: &unsupported},
+			"regional":    {Properties: []string{"stage", "region"}},
+		}),
+	)
+	assert.NoError(t, err)
+
+	nc := c.ToNullLabelContext()
+
+	assert.Equal(t, true, *nc.Enabled)
+	assert.Equal(t, "cp", *nc.Namespace)
+	assert.Nil(t, nc.Tenant)
+	assert.Equal(t, []string{"blue", "1"}, nc.Attributes)
+	assert.Equal(t, "/[^a-z]/", *nc.RegexReplaceChars)
+	assert.Nil(t, nc.LabelKeyCase)
+	assert.Equal(t, "none", *nc.LabelValueCase)
+	assert.Equal(t, []string{"namespace", "stage", "name", NullLabelAttributes}, nc.LabelOrder)
+	assert.Equal(t, []string{NullLabelAttributes, "namespace", "stage"}, nc.LabelsAsTags)
+	assert.Equal(t, map[string]NullLabelDescriptorFormat{
+		"stack": {Format: "%v/%v", Labels: []string{"tenant", "stage"}},
+		"short": {Format: "%v_%v", Labels: []string{"namespace", "name"}},
+	}, nc.DescriptorFormats)
+}
+
+func TestNullLabelContextRoundTrip(t *testing.T) {
+	c, _, err := ParseNullLabelContext([]byte(testNullLabelContext))
+	assert.NoError(t, err)
+	cf, _ := c.ToContextFile()
+	pc, err := NewProviderConfig(cf.Properties, cf.PropertyOrder, cf.Values, cf.Options()...)
+	assert.NoError(t, err)
+
+	nc := pc.ToNullLabelContext()
+	assert.Equal(t, c.LabelOrder, nc.LabelOrder)
+	assert.Equal(t, c.Attributes, nc.Attributes)
+	assert.Equal(t, c.Namespace, nc.Namespace)
+	assert.Equal(t, "/[^\\s\\S]/", *nc.RegexReplaceChars)
+	assert.Equal(t, "none", *nc.LabelValueCase)
+	assert.Equal(t, c.DescriptorFormats["stack"], nc.DescriptorFormats["stack"])
+	assert.ElementsMatch(t, c.LabelsAsTags, nc.LabelsAsTags)
+}
+
+func TestNullLabelContextToFramework(t *testing.T) {
+	c, err := NewProviderConfig([]Property{*NewProperty("name")}, nil, map[string]string{"name": "app"})
+	assert.NoError(t, err)
+
+	obj, diags := types.ObjectValueFrom(context.Background(), NullLabelContextAttrTypes(), c.ToNullLabelContext())
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, types.StringValue("app"), obj.Attributes()["name"])
+	assert.True(t, obj.Attributes()["id_length_limit"].IsNull())
+}
@@ -3,7 +3,9 @@ package model
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/cloudposse/terraform-provider-context/pkg/cases"
@@ -12,12 +14,26 @@ import (
 // PropertyOption is a function that modifies a Property.
 type PropertyOption func(*Property)
 
+// AllowedValue is one of the values a property is limited to, with an optional description of what it means.
+type AllowedValue struct {
+	Value       string
+	Description string
+}
+
 type Property struct {
+	AllowedValues   []AllowedValue
+	Default         string
+	DeriveFrom      string
 	IncludeInTags   bool
+	LabelLookup     string
 	MaxLength       int
 	MinLength       int
 	Name            string
+	Order           int
 	Required        bool
+	RequiredWhen    map[string][]string
+	Severities      map[string]Severity
+	TagLookup       string
 	TagsKeyCase     *cases.Case
 	TagsValueCase   *cases.Case
 	ValidationRegex string
@@ -29,82 +45,177 @@ var (
 	ErrValueTooLong     = errors.New("value is greater than maximum length")
 	ErrInvalidRegex     = errors.New("regex is invalid")
 	ErrRegexMismatch    = errors.New("value does not match regex")
+	ErrValueNotAllowed  = errors.New("value is not allowed")
+
+	ErrUnknownAllowedValue = errors.New("description is for a value that is not allowed")
 )
 
-func (p *Property) Validate(value string) []error {
-	errors := []error{}
+// PropertyError describes a property whose value failed validation. Err wraps one of the property errors, such as
+// ErrValueTooShort, so the kind of error can still be checked with errors.Is. Check is the name of the check that
+// failed, if any, and Severity how the failure is reported.
+type PropertyError struct {
+	Property string
+	Value    string
+	Check    string
+	Severity Severity
+	Err      error
+}
 
-	if err := validateRequired(p.Required, value, p.Name); err != nil {
-		errors = append(errors, err)
-	}
+func (e *PropertyError) Error() string {
+	return e.Err.Error()
+}
 
-	if err := validateMinLength(p.MinLength, value, p.Name); err != nil {
-		errors = append(errors, err)
-	}
+func (e *PropertyError) Unwrap() error {
+	return e.Err
+}
 
-	if err := validateMaxLength(p.MaxLength, value, p.Name); err != nil {
-		errors = append(errors, err)
+// Validate validates the value and returns the checks that failed, each with the severity set for it.
+func (p *Property) Validate(value string) ValidationResults {
+	return p.validate(value, func(regex string) (*regexp.Regexp, error) {
+		return regexp.Compile(regex)
+	})
+}
+
+// validate validates the value, getting the compiled validation regex from getRegex.
+func (p *Property) validate(value string, getRegex func(string) (*regexp.Regexp, error)) ValidationResults {
+	checks := []struct {
+		check string
+		err   *PropertyError
+	}{
+		{CheckRequired, validateRequired(p.Required, value, p.Name)},
+		{CheckMinLength, validateMinLength(p.MinLength, value, p.Name)},
+		{CheckMaxLength, validateMaxLength(p.MaxLength, value, p.Name)},
+		{CheckValidationRegex, validateRegex(p.ValidationRegex, value, p.Name, getRegex)},
+		{CheckAllowedValues, validateAllowedValues(p.AllowedValues, value, p.Name)},
 	}
 
-	if err := validateRegex(p.ValidationRegex, value, p.Name); err != nil {
-		errors = append(errors, err)
+	results := ValidationResults{}
+	for _, c := range checks {
+		if c.err != nil {
+			results = append(results, p.withSeverity(c.check, c.err))
+		}
 	}
+	return results
+}
 
-	return errors
+// withSeverity sets the check and its severity on a failed check. An invalid regex is a problem with the property
+// rather than the value, so it is always an error.
+func (p *Property) withSeverity(check string, err *PropertyError) *PropertyError {
+	err.Check = check
+	err.Severity = p.getSeverity(check)
+	if errors.Is(err.Err, ErrInvalidRegex) {
+		err.Severity = SeverityError
+	}
+	return err
 }
 
-func validateRequired(required bool, value string, propertyName string) error {
+func validateRequired(required bool, value string, propertyName string) *PropertyError {
 	if required && strings.TrimSpace(value) == "" {
-		return fmt.Errorf("%w: value for property %s", ErrPropertyRequired, propertyName)
+		return &PropertyError{Property: propertyName, Value: value, Err: fmt.Errorf("%w: value for property %s", ErrPropertyRequired, propertyName)}
 	}
 	return nil
 }
 
-func validateMinLength(minLength int, value string, propertyName string) error {
+func validateMinLength(minLength int, value string, propertyName string) *PropertyError {
 	if minLength == 0 {
 		return nil
 	}
 
 	if len(value) < minLength {
-		return fmt.Errorf("%w: value %s for property %s is less than %d", ErrValueTooShort, value, propertyName, minLength)
+		return &PropertyError{Property: propertyName, Value: value, Err: fmt.Errorf("%w: value %s for property %s is less than %d", ErrValueTooShort, value, propertyName, minLength)}
 	}
 	return nil
 }
 
-func validateMaxLength(maxLength int, value string, propertyName string) error {
+func validateMaxLength(maxLength int, value string, propertyName string) *PropertyError {
 	if maxLength == 0 {
 		return nil
 	}
 
 	if len(value) > maxLength {
-		return fmt.Errorf("%w: value %s for property %s is greater than %d", ErrValueTooLong, value, propertyName, maxLength)
+		return &PropertyError{Property: propertyName, Value: value, Err: fmt.Errorf("%w: value %s for property %s is greater than %d", ErrValueTooLong, value, propertyName, maxLength)}
 	}
 	return nil
 }
 
-func validateRegex(regex string, value string, propertyName string) error {
+func validateRegex(regex string, value string, propertyName string, getRegex func(string) (*regexp.Regexp, error)) *PropertyError {
 	if regex == "" || value == "" {
 		return nil
 	}
 
-	r, err := regexp.Compile(regex)
+	r, err := getRegex(regex)
 	if err != nil {
-		return fmt.Errorf("%w: %s for property %s", ErrInvalidRegex, regex, propertyName)
+		return &PropertyError{Property: propertyName, Value: value, Err: invalidValidationRegexError(regex, propertyName)}
 	}
 
 	if !r.MatchString(value) {
-		return fmt.Errorf("%w: value %s for property %s does not match %s", ErrRegexMismatch, value, propertyName, regex)
+		return &PropertyError{Property: propertyName, Value: value, Err: fmt.Errorf("%w: value %s for property %s does not match %s", ErrRegexMismatch, value, propertyName, regex)}
 	}
 	return nil
 }
 
+// validateAllowedValues checks that a value is one of the allowed values, if the property has any. Like the regex, an
+// empty value is left to the required check.
+func validateAllowedValues(allowedValues []AllowedValue, value string, propertyName string) *PropertyError {
+	if len(allowedValues) == 0 || value == "" {
+		return nil
+	}
+
+	choices := make([]string, 0, len(allowedValues))
+	for _, av := range allowedValues {
+		if av.Value == value {
+			return nil
+		}
+		choices = append(choices, av.Value)
+	}
+	return &PropertyError{Property: propertyName, Value: value, Err: fmt.Errorf("%w: value %s for property %s is not one of: %s", ErrValueNotAllowed, value, propertyName, strings.Join(choices, ", "))}
+}
+
+// GetAllowedValueNames returns the allowed values of the property, without their descriptions.
+func (p *Property) GetAllowedValueNames() []string {
+	names := make([]string, 0, len(p.AllowedValues))
+	for _, av := range p.AllowedValues {
+		names = append(names, av.Value)
+	}
+	return names
+}
+
+// NewAllowedValues returns the allowed values in the given order with the given descriptions. Every description must
+// be for one of the values.
+func NewAllowedValues(values []string, descriptions map[string]string) ([]AllowedValue, error) {
+	allowedValues := make([]AllowedValue, 0, len(values))
+	for _, v := range values {
+		allowedValues = append(allowedValues, AllowedValue{Value: v, Description: descriptions[v]})
+	}
+
+	for _, v := range slices.Sorted(maps.Keys(descriptions)) {
+		if !slices.Contains(values, v) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownAllowedValue, v)
+		}
+	}
+	return allowedValues, nil
+}
+
+// invalidValidationRegexError returns the error for a validation regex of a property that does not compile.
+func invalidValidationRegexError(regex string, propertyName string) error {
+	return fmt.Errorf("%w: %s for property %s", ErrInvalidRegex, regex, propertyName)
+}
+
 func NewProperty(name string, options ...PropertyOption) *Property {
 	defaults := &Property{
+		AllowedValues:   nil,
+		Default:         "",
+		DeriveFrom:      "",
 		IncludeInTags:   true,
+		LabelLookup:     "",
 		MaxLength:       0,
 		MinLength:       0,
 		Name:            name,
+		Order:           0,
 		Required:        false,
+		RequiredWhen:    nil,
+		Severities:      nil,
+		TagLookup:       "",
 		TagsKeyCase:     nil,
 		TagsValueCase:   nil,
 		ValidationRegex: "",
@@ -117,6 +228,28 @@ func NewProperty(name string, options ...PropertyOption) *Property {
 	return defaults
 }
 
+// WithAllowedValues limits the values of the property to the given values.
+func WithAllowedValues(allowedValues []AllowedValue) func(*Property) {
+	return func(obj *Property) {
+		obj.AllowedValues = allowedValues
+	}
+}
+
+// WithDefault sets the value the property has when no value is set for it.
+func WithDefault(value string) func(*Property) {
+	return func(obj *Property) {
+		obj.Default = value
+	}
+}
+
+// WithDeriveFrom sets the template the value of the property is derived from when no value is set for it, e.g.
+// "{{.namespace}}-{{.stage}}".
+func WithDeriveFrom(template string) func(*Property) {
+	return func(obj *Property) {
+		obj.DeriveFrom = template
+	}
+}
+
 func WithRequired() func(*Property) {
 	return func(obj *Property) {
 		obj.Required = true
@@ -141,6 +274,13 @@ func WithMaxLength(maxLength int) func(*Property) {
 	}
 }
 
+// WithOrder sets the position of the property in the default property order.
+func WithOrder(order int) func(*Property) {
+	return func(obj *Property) {
+		obj.Order = order
+	}
+}
+
 func WithValidationRegex(regex string) func(*Property) {
 	return func(obj *Property) {
 		obj.ValidationRegex = regex
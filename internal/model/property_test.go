@@ -1,6 +1,7 @@
 package model
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -46,6 +47,13 @@ func TestNewPropertyWithMaxLength(t *testing.T) {
 	assert.Equal(t, "", p.ValidationRegex)
 }
 
+func TestNewPropertyWithOrder(t *testing.T) {
+	p := NewProperty("test", WithOrder(3))
+
+	assert.Equal(t, "test", p.Name)
+	assert.Equal(t, 3, p.Order)
+}
+
 func TestNewPropertyWithValidationRegex(t *testing.T) {
 	p := NewProperty("test", WithValidationRegex("^[a-z]+$"))
 
@@ -130,3 +138,65 @@ func TestPropertyExcludeFromTags(t *testing.T) {
 	actual := p.IncludeInTags
 	assert.Equal(t, false, actual)
 }
+
+func TestPropertyValidateReturnsPropertyErrors(t *testing.T) {
+	p := NewProperty("test", WithMinLength(5), WithValidationRegex("^[a-z]+$"))
+
+	errs := p.Validate("123")
+
+	assert.Equal(t, 2, len(errs))
+
+	var propertyErr *PropertyError
+	assert.True(t, errors.As(errs[0], &propertyErr))
+	assert.Equal(t, "test", propertyErr.Property)
+	assert.Equal(t, "123", propertyErr.Value)
+	assert.ErrorIs(t, errs[0], ErrValueTooShort)
+
+	assert.True(t, errors.As(errs[1], &propertyErr))
+	assert.Equal(t, "test", propertyErr.Property)
+	assert.ErrorIs(t, errs[1], ErrRegexMismatch)
+}
+
+func TestPropertyValidateWithInvalidRegexReturnsPropertyError(t *testing.T) {
+	p := NewProperty("test", WithValidationRegex("["))
+
+	errs := p.Validate("abc")
+
+	assert.Equal(t, 1, len(errs))
+	var propertyErr *PropertyError
+	assert.True(t, errors.As(errs[0], &propertyErr))
+	assert.Equal(t, "test", propertyErr.Property)
+	assert.ErrorIs(t, errs[0], ErrInvalidRegex)
+}
+
+func TestPropertyValidateWithAllowedValuesInvalid(t *testing.T) {
+	p := NewProperty("stage", WithAllowedValues([]AllowedValue{{Value: "dev"}, {Value: "staging"}, {Value: "prod", Description: "Production"}}))
+
+	errs := p.Validate("qa")
+
+	assert.Equal(t, 1, len(errs))
+	assert.ErrorIs(t, errs[0], ErrValueNotAllowed)
+	assert.Equal(t, "value is not allowed: value qa for property stage is not one of: dev, staging, prod", errs[0].Error())
+
+	var propertyErr *PropertyError
+	assert.True(t, errors.As(errs[0], &propertyErr))
+	assert.Equal(t, "stage", propertyErr.Property)
+}
+
+func TestPropertyValidateWithAllowedValuesValid(t *testing.T) {
+	p := NewProperty("stage", WithAllowedValues([]AllowedValue{{Value: "dev"}, {Value: "prod"}}))
+
+	assert.Empty(t, p.Validate("prod"))
+	// An empty value is only checked by required
+	assert.Empty(t, p.Validate(""))
+}
+
+func TestNewAllowedValues(t *testing.T) {
+	allowedValues, err := NewAllowedValues([]string{"dev", "prod"}, map[string]string{"prod": "Production"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []AllowedValue{{Value: "dev"}, {Value: "prod", Description: "Production"}}, allowedValues)
+
+	_, err = NewAllowedValues([]string{"dev", "prod"}, map[string]string{"qa": "Quality assurance"})
+	assert.ErrorIs(t, err, ErrUnknownAllowedValue)
+}
@@ -7,7 +7,6 @@ import (
 	"regexp"
 	"sort"
 	"strings"
-	"text/template"
 
 	"github.com/cloudposse/terraform-provider-context/pkg/cases"
 	"github.com/cloudposse/terraform-provider-context/pkg/slice"
@@ -16,15 +15,32 @@ import (
 
 var ErrLabelTooLong = errors.New("label exceeds maximum length")
 
+const (
+	// ValueSourceConfigFile marks a value read from the context file.
+	ValueSourceConfigFile = "config_file"
+	// ValueSourceEnvironment marks a value read from an environment variable.
+	ValueSourceEnvironment = "environment"
+	// ValueSourceProvider marks a value set in the provider configuration.
+	ValueSourceProvider = "provider"
+)
+
 type ProviderConfig struct {
 	delimiter         string
 	enabled           bool
+	labelFormats      map[string]LabelFormat
+	lookups           map[string]Lookup
 	properties        []Property
 	propertyOrder     []string
 	replaceCharsRegex string
 	tagsKeyCase       cases.Case
 	tagsValueCase     cases.Case
 	values            map[string]string
+	valueSources      map[string]string
+
+	validations []ValidationRule
+
+	derivations []derivation
+	engine      *renderEngine
 }
 
 type DelmitedLabelOptions struct {
@@ -43,17 +59,48 @@ func (c *ProviderConfig) IsEnabled() bool {
 	return c.enabled
 }
 
+// GetMergedEnabled returns the enabled flag passed in to the function or, if it is nil, the enabled flag from the
+// context.
+func (c *ProviderConfig) GetMergedEnabled(enabled *bool) bool {
+	if enabled != nil {
+		return *enabled
+	}
+	return c.enabled
+}
+
 // GetProperties returns the properties from the context.
 func (c *ProviderConfig) GetProperties() []Property {
 	return c.properties
 }
 
+// ValidateProperties validates the values against the properties, including the required_when conditions of each
+// property, which are checked against the other values, then runs the validation rules of the context.
 func (c *ProviderConfig) ValidateProperties(values map[string]string) []error {
 	errors := []error{}
 	for _, p := range c.properties {
-		errors = append(errors, p.Validate(values[p.Name])...)
+		errors = append(errors, p.validate(values[p.Name], c.engine.getRegex).Errors()...)
+		if err := p.validateRequiredWhen(values, nil, c.engine.getRegex); err != nil {
+			errors = append(errors, err)
+		}
 	}
-	return errors
+	return append(errors, c.validateRules(values, nil)...)
+}
+
+// ValidateKnownProperties validates the values from the context, overridden by the values passed in to the function,
+// skipping the properties whose values are not known yet, including values derived from them. Unknown values are
+// computed during apply, so they are validated when the label or tags are rendered.
+func (c *ProviderConfig) ValidateKnownProperties(values map[string]string, unknown []string) []error {
+	mergedValues, unknown, errors := c.resolveValues(values, unknown)
+	for _, p := range c.properties {
+		if slice.Contains(unknown, p.Name) {
+			continue
+		}
+		errors = append(errors, p.validate(mergedValues[p.Name], c.engine.getRegex).Errors()...)
+		if err := p.validateRequiredWhen(mergedValues, unknown, c.engine.getRegex); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return append(errors, c.validateRules(mergedValues, unknown)...)
 }
 
 // GetPropertyNames returns the names of the properties from the context.
@@ -146,18 +193,25 @@ func (c *ProviderConfig) GetValues() map[string]string {
 	return c.values
 }
 
-// getMergedValues merges the values from the context with the values passed in to the function to derive the values to
-// use when creating a label.
-func (c *ProviderConfig) GetMergedValues(values map[string]string) map[string]string {
-	mergedValues := make(map[string]string, len(c.values))
-	for key, value := range c.values {
-		mergedValues[key] = value
-	}
-
-	for key, value := range values {
-		mergedValues[key] = value
+// GetValueSources returns the source that supplied each value in the context. Values without a recorded source were
+// set in the provider configuration.
+func (c *ProviderConfig) GetValueSources() map[string]string {
+	sources := make(map[string]string, len(c.values))
+	for key := range c.values {
+		source, ok := c.valueSources[key]
+		if !ok || source == "" {
+			source = ValueSourceProvider
+		}
+		sources[key] = source
 	}
+	return sources
+}
 
+// GetMergedValues merges the values from the context with the values passed in to the function and fills in defaults
+// and derived values to derive the values to use when creating a label. See ResolveValues for the errors of derived
+// values.
+func (c *ProviderConfig) GetMergedValues(values map[string]string) map[string]string {
+	mergedValues, _ := c.ResolveValues(values)
 	return mergedValues
 }
 
@@ -172,26 +226,76 @@ func (c *ProviderConfig) getOrderedValues(propertyOrder []string, values map[str
 	return orderedValues
 }
 
-func getRedactedLabel(label string, regex string) (string, error) {
-	if regex == "" {
-		return label, nil
-	}
-
-	compiledRegex, err := regexp.Compile(regex)
+// RedactLabel removes every match of the regex from the label. An empty regex leaves the label unchanged.
+func RedactLabel(label string, regex string) (string, error) {
+	compiledRegex, err := compileRedactRegex(regex)
 	if err != nil {
 		return "", err
 	}
+	if compiledRegex == nil {
+		return label, nil
+	}
 	replaced := compiledRegex.ReplaceAllString(label, "")
 	return replaced, nil
 }
 
+// compileRedactRegex compiles the regex used to redact labels. An empty regex returns nil.
+func compileRedactRegex(regex string) (*regexp.Regexp, error) {
+	if regex == "" {
+		return nil, nil //nolint:nilnil
+	}
+	return regexp.Compile(regex)
+}
+
+// TruncateLabel shortens a label that exceeds maxLength by replacing its end with a hash of the full label. If truncate
+// is false, an error is returned instead. A maxLength of 0 means the label is not limited.
+func TruncateLabel(label string, maxLength int, truncate bool) (string, error) {
+	if maxLength > 0 && len(label) > maxLength {
+		if !truncate {
+			return "", fmt.Errorf("%w: %s (max: %d)", ErrLabelTooLong, label, maxLength)
+		}
+		return stringHelpers.TruncateWithHash(label, maxLength), nil
+	}
+
+	return label, nil
+}
+
 //nolint:revive
 func (c *ProviderConfig) GetDelimitedLabel(delimiter *string, properties []string, propertyOrder []string, values map[string]string, replaceCharsRegex *string, maxLength int, truncateIfExceedsMaxLength bool) (string, []error) {
-	mergedValues := c.GetMergedValues(values)
+	label, _, errs := c.ExplainDelimitedLabel(delimiter, properties, propertyOrder, values, replaceCharsRegex, maxLength, truncateIfExceedsMaxLength)
+	return label, errs
+}
+
+// ExplainDelimitedLabel returns the same label as GetDelimitedLabel along with an explanation of how it was built.
+//
+//nolint:revive
+func (c *ProviderConfig) ExplainDelimitedLabel(delimiter *string, properties []string, propertyOrder []string, values map[string]string, replaceCharsRegex *string, maxLength int, truncateIfExceedsMaxLength bool) (string, *Explanation, []error) {
+	key := renderKey{
+		Kind:              "delimited",
+		Delimiter:         delimiter,
+		Properties:        properties,
+		PropertyOrder:     propertyOrder,
+		Values:            values,
+		ReplaceCharsRegex: replaceCharsRegex,
+		MaxLength:         maxLength,
+		Truncate:          truncateIfExceedsMaxLength,
+	}
+	result := c.engine.render(key, func() renderResult {
+		label, explanation, errs := c.renderDelimitedLabel(delimiter, properties, propertyOrder, values, replaceCharsRegex, maxLength, truncateIfExceedsMaxLength)
+		return renderResult{label: label, explanation: explanation, errs: errs}
+	})
+	return result.label, result.explanation, result.errs
+}
+
+// renderDelimitedLabel renders a delimited label without the render cache.
+//
+//nolint:revive
+func (c *ProviderConfig) renderDelimitedLabel(delimiter *string, properties []string, propertyOrder []string, values map[string]string, replaceCharsRegex *string, maxLength int, truncateIfExceedsMaxLength bool) (string, *Explanation, []error) {
+	mergedValues, validationErrors := c.ResolveValues(values)
 	regex := c.GetMergedReplaceCharsRegex(replaceCharsRegex)
-	validationErrors := c.ValidateProperties(mergedValues)
-	if len(validationErrors) > 0 {
-		return "", validationErrors
+	validationErrors = append(validationErrors, c.ValidateProperties(mergedValues)...)
+	if HasErrors(validationErrors) {
+		return "", nil, validationErrors
 	}
 
 	mergedDelimiter := c.GetMergedDelimiter(delimiter)
@@ -203,60 +307,99 @@ func (c *ProviderConfig) GetDelimitedLabel(delimiter *string, properties []strin
 			filteredPropertyOrder = append(filteredPropertyOrder, prop)
 		}
 	}
-	orderedValues := c.getOrderedValues(filteredPropertyOrder, mergedValues)
+	labelValues, lookupErrors := c.getLabelValues(mergedValues)
+	if len(lookupErrors) > 0 {
+		return "", nil, append(validationErrors, lookupErrors...)
+	}
+	orderedValues := c.getOrderedValues(filteredPropertyOrder, labelValues)
 
 	label := strings.Join(orderedValues, mergedDelimiter)
 
-	redactedLabel, err := getRedactedLabel(label, regex)
+	compiledRegex, err := c.engine.getRedactRegex(regex)
 	if err != nil {
-		return "", []error{err}
+		return "", nil, []error{err}
 	}
+	explanation := c.explainDelimitedLabel(mergedProperties, mergedPropertyOrder, labelValues, values, compiledRegex)
 
-	if maxLength > 0 && len(redactedLabel) > maxLength {
-		if !truncateIfExceedsMaxLength {
-			return "", []error{fmt.Errorf("%w: %s (max: %d)", ErrLabelTooLong, redactedLabel, maxLength)}
-		}
-		return stringHelpers.TruncateWithHash(redactedLabel, maxLength), nil
-	}
-
-	return redactedLabel, nil
+	label, explanation, errs := finishLabel(label, compiledRegex, maxLength, truncateIfExceedsMaxLength, explanation)
+	return label, explanation, append(getWarnings(validationErrors), errs...)
 }
 
 // GetTemplatedLabel returns a label from the template string and based on the properties and values in the context and
 // overridden by the delimiter, properties and values passed into the function.
 func (c *ProviderConfig) GetTemplatedLabel(templateString string, values map[string]string, replaceCharsRegex *string, maxLength int, truncateIfExceedsMaxLength bool) (string, []error) {
-	mergedValues := c.GetMergedValues(values)
+	label, _, errs := c.ExplainTemplatedLabel(templateString, values, replaceCharsRegex, maxLength, truncateIfExceedsMaxLength)
+	return label, errs
+}
+
+// ExplainTemplatedLabel returns the same label as GetTemplatedLabel along with an explanation of how it was built.
+func (c *ProviderConfig) ExplainTemplatedLabel(templateString string, values map[string]string, replaceCharsRegex *string, maxLength int, truncateIfExceedsMaxLength bool) (string, *Explanation, []error) {
+	key := renderKey{
+		Kind:              "templated",
+		Template:          templateString,
+		Values:            values,
+		ReplaceCharsRegex: replaceCharsRegex,
+		MaxLength:         maxLength,
+		Truncate:          truncateIfExceedsMaxLength,
+	}
+	result := c.engine.render(key, func() renderResult {
+		label, explanation, errs := c.renderTemplatedLabel(templateString, values, replaceCharsRegex, maxLength, truncateIfExceedsMaxLength)
+		return renderResult{label: label, explanation: explanation, errs: errs}
+	})
+	return result.label, result.explanation, result.errs
+}
+
+// renderTemplatedLabel renders a templated label without the render cache.
+func (c *ProviderConfig) renderTemplatedLabel(templateString string, values map[string]string, replaceCharsRegex *string, maxLength int, truncateIfExceedsMaxLength bool) (string, *Explanation, []error) {
+	mergedValues, validationErrors := c.ResolveValues(values)
 	regex := c.GetMergedReplaceCharsRegex(replaceCharsRegex)
-	validationErrors := c.ValidateProperties(mergedValues)
-	if len(validationErrors) > 0 {
-		return "", validationErrors
+	validationErrors = append(validationErrors, c.ValidateProperties(mergedValues)...)
+	if HasErrors(validationErrors) {
+		return "", nil, validationErrors
 	}
 
-	tmpl, err := template.New("label").Parse(templateString)
+	tmpl, err := c.engine.getTemplate(templateString)
 	if err != nil {
-		return "", []error{err}
+		return "", nil, []error{err}
+	}
+
+	labelValues, lookupErrors := c.getLabelValues(mergedValues)
+	if len(lookupErrors) > 0 {
+		return "", nil, append(validationErrors, lookupErrors...)
 	}
 
 	var result bytes.Buffer
-	err = tmpl.Execute(&result, mergedValues)
+	err = tmpl.Execute(&result, labelValues)
 	if err != nil {
-		return "", []error{err}
+		return "", nil, []error{err}
 	}
 
-	label := result.String()
-	redactedLabel, err := getRedactedLabel(label, regex)
+	compiledRegex, err := c.engine.getRedactRegex(regex)
 	if err != nil {
-		return "", []error{err}
+		return "", nil, []error{err}
 	}
+	explanation := c.explainTemplatedLabel(tmpl, labelValues, values, compiledRegex)
 
-	if maxLength > 0 && len(redactedLabel) > maxLength {
-		if !truncateIfExceedsMaxLength {
-			return "", []error{fmt.Errorf("%w: %s (max: %d)", ErrLabelTooLong, redactedLabel, maxLength)}
-		}
-		return stringHelpers.TruncateWithHash(redactedLabel, maxLength), nil
+	label, explanation, errs := finishLabel(result.String(), compiledRegex, maxLength, truncateIfExceedsMaxLength, explanation)
+	return label, explanation, append(getWarnings(validationErrors), errs...)
+}
+
+// finishLabel redacts and truncates a label and records in the explanation whether it was truncated.
+func finishLabel(label string, regex *regexp.Regexp, maxLength int, truncate bool, explanation *Explanation) (string, *Explanation, []error) {
+	redactedLabel := label
+	if regex != nil {
+		redactedLabel = regex.ReplaceAllString(label, "")
+		explanation.Removed = strings.Join(regex.FindAllString(label, -1), "")
+	}
+
+	truncatedLabel, err := TruncateLabel(redactedLabel, maxLength, truncate)
+	if err != nil {
+		return "", nil, []error{err}
 	}
 
-	return redactedLabel, nil
+	explanation.Truncated = truncatedLabel != redactedLabel
+	explanation.Untruncated = redactedLabel
+	return truncatedLabel, explanation, nil
 }
 
 func getCasedTag(key string, value string, keyCase cases.Case, valueCase cases.Case) (string, string) {
@@ -267,18 +410,49 @@ func getCasedTag(key string, value string, keyCase cases.Case, valueCase cases.C
 }
 
 func (c *ProviderConfig) GetTags(values map[string]string, tagsKeyCase *cases.Case, tagsValueCase *cases.Case) (map[string]string, []error) {
+	tags, _, errs := c.ExplainTags(values, tagsKeyCase, tagsValueCase)
+	return tags, errs
+}
+
+// ExplainTags returns the same tags as GetTags along with an explanation of which properties became tags.
+func (c *ProviderConfig) ExplainTags(values map[string]string, tagsKeyCase *cases.Case, tagsValueCase *cases.Case) (map[string]string, *Explanation, []error) {
+	key := renderKey{
+		Kind:          "tags",
+		Values:        values,
+		TagsKeyCase:   getCaseKey(tagsKeyCase),
+		TagsValueCase: getCaseKey(tagsValueCase),
+	}
+	result := c.engine.render(key, func() renderResult {
+		tags, explanation, errs := c.renderTags(values, tagsKeyCase, tagsValueCase)
+		return renderResult{tags: tags, explanation: explanation, errs: errs}
+	})
+	return result.tags, result.explanation, result.errs
+}
+
+// renderTags renders tags without the render cache.
+func (c *ProviderConfig) renderTags(values map[string]string, tagsKeyCase *cases.Case, tagsValueCase *cases.Case) (map[string]string, *Explanation, []error) {
 	tags := map[string]string{}
-	mergedValues := c.GetMergedValues(values)
-	validationErrors := c.ValidateProperties(mergedValues)
+	mergedValues, validationErrors := c.ResolveValues(values)
+	validationErrors = append(validationErrors, c.ValidateProperties(mergedValues)...)
 	mergedTagsKeyCase := c.GetMergedTagsKeyCase(tagsKeyCase)
 	mergedTagsValueCase := c.GetMergedTagsValueCase(tagsValueCase)
 
-	if len(validationErrors) > 0 {
-		return tags, validationErrors
+	if HasErrors(validationErrors) {
+		return tags, nil, validationErrors
+	}
+
+	// Values are validated before the lookups replace them
+	tagValues, lookupErrors := c.getTagValues(mergedValues)
+	if len(lookupErrors) > 0 {
+		return tags, nil, append(validationErrors, lookupErrors...)
 	}
 
+	explanation := &Explanation{}
 	for _, p := range c.properties {
+		pe := c.explainProperty(p.Name, tagValues, values, nil)
 		if !p.IncludeInTags {
+			pe.Reason = ReasonExcludedFromTags
+			explanation.Properties = append(explanation.Properties, pe)
 			continue
 		}
 		// Use property-specific cases if available, otherwise use merged cases
@@ -290,18 +464,22 @@ func (c *ProviderConfig) GetTags(values map[string]string, tagsKeyCase *cases.Ca
 		if p.TagsValueCase != nil {
 			valueCase = *p.TagsValueCase
 		}
-		key, value := getCasedTag(p.Name, mergedValues[p.Name], keyCase, valueCase)
+		key, value := getCasedTag(p.Name, tagValues[p.Name], keyCase, valueCase)
+		pe.Reason = ReasonEmptyValue
 		if value != "" {
 			tags[key] = value
+			pe.Included = true
+			pe.Reason = ReasonIncluded
 		}
+		explanation.Properties = append(explanation.Properties, pe)
 	}
-	return tags, nil
+	return tags, explanation, getWarnings(validationErrors)
 }
 
 func (c *ProviderConfig) GetTagsAsList(values map[string]string, tagsKeyCase *cases.Case, tagsValueCase *cases.Case) ([]map[string]string, []error) {
-	tags, err := c.GetTags(values, tagsKeyCase, tagsValueCase)
-	if err != nil {
-		return nil, err
+	tags, errs := c.GetTags(values, tagsKeyCase, tagsValueCase)
+	if HasErrors(errs) {
+		return nil, errs
 	}
 
 	tagsList := []map[string]string{}
@@ -316,7 +494,26 @@ func (c *ProviderConfig) GetTagsAsList(values map[string]string, tagsKeyCase *ca
 	for _, k := range keys {
 		tagsList = append(tagsList, map[string]string{"Key": k, "Value": tags[k]})
 	}
-	return tagsList, nil
+	return tagsList, errs
+}
+
+// getDefaultPropertyOrder returns the names of the properties sorted by their order, breaking ties alphabetically, so
+// the default order does not depend on the order the properties were declared or read in.
+func getDefaultPropertyOrder(properties []Property) []string {
+	sorted := make([]Property, len(properties))
+	copy(sorted, properties)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Order != sorted[j].Order {
+			return sorted[i].Order < sorted[j].Order
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	names := make([]string, 0, len(sorted))
+	for _, p := range sorted {
+		names = append(names, p.Name)
+	}
+	return names
 }
 
 // NewProviderConfig is the factory for creating a new provider config.
@@ -324,6 +521,8 @@ func NewProviderConfig(properties []Property, propertyOrder []string, values map
 	cc := &ProviderConfig{
 		delimiter:         "-",
 		enabled:           true,
+		labelFormats:      map[string]LabelFormat{},
+		lookups:           map[string]Lookup{},
 		properties:        properties,
 		replaceCharsRegex: "",
 		tagsKeyCase:       cases.TitleCase,
@@ -331,16 +530,54 @@ func NewProviderConfig(properties []Property, propertyOrder []string, values map
 		values:            values,
 	}
 
-	cc.propertyOrder = cc.GetMergedPropertyOrder(cc.GetPropertyNames(properties))
+	cc.propertyOrder = cc.GetMergedPropertyOrder(getDefaultPropertyOrder(properties))
 	cc.propertyOrder = cc.GetMergedPropertyOrder(propertyOrder)
 
 	for _, option := range options {
 		option(cc)
 	}
 
+	if err := cc.validateLabelFormats(); err != nil {
+		return nil, err
+	}
+
+	if err := cc.validateLookups(); err != nil {
+		return nil, err
+	}
+
+	derivations, err := cc.newDerivations()
+	if err != nil {
+		return nil, err
+	}
+	cc.derivations = derivations
+
+	for _, r := range cc.validations {
+		if err := r.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	// The settings of the context do not change after this point, so its regexes and templates are compiled once
+	cc.engine = newRenderEngine(cc)
+
 	return cc, nil
 }
 
+// Options returns the functional options that recreate the settings of this context, other than its properties,
+// property order and values, when creating a new provider config.
+func (c *ProviderConfig) Options() []func(*ProviderConfig) {
+	return []func(*ProviderConfig){
+		WithDelimiter(c.delimiter),
+		WithEnabled(c.enabled),
+		WithLabelFormats(c.labelFormats),
+		WithLookups(c.lookups),
+		WithReplaceCharsRegex(c.replaceCharsRegex),
+		WithTagsKeyCase(c.tagsKeyCase),
+		WithTagsValueCase(c.tagsValueCase),
+		WithValidationRules(c.validations),
+	}
+}
+
 // WithProperties is a functional option for setting the properties in the context when creating a new provider config.
 func WithEnabled(enabled bool) func(*ProviderConfig) {
 	return func(obj *ProviderConfig) {
@@ -362,6 +599,13 @@ func WithReplaceCharsRegex(regex string) func(*ProviderConfig) {
 	}
 }
 
+// WithValueSources is a functional option for recording the source of each value in the context.
+func WithValueSources(sources map[string]string) func(*ProviderConfig) {
+	return func(obj *ProviderConfig) {
+		obj.valueSources = sources
+	}
+}
+
 func WithTagsKeyCase(keyCase cases.Case) func(*ProviderConfig) {
 	return func(obj *ProviderConfig) {
 		obj.tagsKeyCase = keyCase
@@ -7,7 +7,7 @@ import (
 )
 
 func getDefaultProviderConfig(t *testing.T, enabled bool) *ProviderConfig {
-	properties := []Property{*NewProperty("foo"), *NewProperty("bar"), *NewProperty("baz")}
+	properties := []Property{*NewProperty("foo", WithOrder(1)), *NewProperty("bar", WithOrder(2)), *NewProperty("baz", WithOrder(3))}
 	values := map[string]string{"foo": "foo", "bar": "bar", "baz": "baz"}
 
 	options := []func(*ProviderConfig){}
@@ -148,3 +148,43 @@ func TestProviderConfigGetTagsDefaultCase(t *testing.T) {
 	assert.Equal(t, 0, len(errs))
 	assert.Equal(t, map[string]string{"Foo": "bar", "Bar": "baz", "Baz": "baz"}, tags)
 }
+
+func TestProviderConfigGetMergedEnabled(t *testing.T) {
+	c := getDefaultProviderConfig(t, false)
+	assert.Equal(t, false, c.GetMergedEnabled(nil))
+
+	enabled := true
+	assert.Equal(t, true, c.GetMergedEnabled(&enabled))
+}
+
+func TestProviderConfigDefaultPropertyOrderUsesOrder(t *testing.T) {
+	properties := []Property{*NewProperty("name", WithOrder(3)), *NewProperty("namespace", WithOrder(1)), *NewProperty("stage", WithOrder(2))}
+	c, err := NewProviderConfig(properties, nil, map[string]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"namespace", "stage", "name"}, c.GetPropertyOrder())
+}
+
+func TestProviderConfigDefaultPropertyOrderBreaksTiesAlphabetically(t *testing.T) {
+	properties := []Property{*NewProperty("stage"), *NewProperty("name", WithOrder(1)), *NewProperty("tenant"), *NewProperty("namespace")}
+	c, err := NewProviderConfig(properties, nil, map[string]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"namespace", "stage", "tenant", "name"}, c.GetPropertyOrder())
+}
+
+func TestProviderConfigExplicitPropertyOrderOverridesOrder(t *testing.T) {
+	properties := []Property{*NewProperty("foo", WithOrder(2)), *NewProperty("bar", WithOrder(1))}
+	c, err := NewProviderConfig(properties, []string{"foo", "bar"}, map[string]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar"}, c.GetPropertyOrder())
+}
+
+func TestProviderConfigValidateKnownProperties(t *testing.T) {
+	properties := []Property{*NewProperty("namespace", WithRequired()), *NewProperty("stage", WithValidationRegex("^(dev|prod)$"))}
+	c, err := NewProviderConfig(properties, nil, map[string]string{"stage": "prod"})
+	assert.NoError(t, err)
+
+	// The required namespace is not known yet, so only the stage is validated
+	assert.Empty(t, c.ValidateKnownProperties(map[string]string{"stage": "dev"}, []string{"namespace"}))
+	assert.Len(t, c.ValidateKnownProperties(map[string]string{"stage": "qa"}, []string{"namespace"}), 1)
+	assert.Len(t, c.ValidateKnownProperties(map[string]string{}, nil), 1)
+}
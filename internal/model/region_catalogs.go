@@ -0,0 +1,193 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// RegionStyleShort is the style of the shortest region codes, e.g. uw2 for us-west-2. Codes vary in length.
+	RegionStyleShort = "short"
+	// RegionStyleFixed is the style of region codes that have the same length within a cloud, e.g. usw2 for us-west-2.
+	RegionStyleFixed = "fixed"
+)
+
+// RegionStyles are the styles of the built-in region catalogs.
+var RegionStyles = []string{RegionStyleFixed, RegionStyleShort}
+
+// regionCodes are the codes of a region in each style.
+type regionCodes struct {
+	fixed string
+	short string
+}
+
+// regionCatalogs holds the regions of each cloud with their codes.
+//
+// The AWS codes are the to_fixed and to_short maps of the region_az_alt_code_maps module of cloudposse's
+// terraform-aws-utils.
+//
+// The Azure short codes are the geo-codes Microsoft publishes for Azure Backup
+// (https://learn.microsoft.com/azure/backup/scripts/geo-code-list). The fixed codes are the ISO 3166-1 alpha-2 code of
+// the physical location of the region, as listed by `az account list-locations`, the direction in the region name
+// abbreviated to two letters and the number of the region, e.g. dewc1 for germanywestcentral in Frankfurt.
+//
+// GCP region names are a geography, a direction and a number
+// (https://cloud.google.com/compute/docs/regions-zones). The fixed codes abbreviate the geography and the direction
+// to two letters each and pad the number to two digits, e.g. euwe01 for europe-west1. The short codes abbreviate the
+// geography to its initial, or to two letters for africa, australia, me, northamerica and southamerica, the direction
+// to its initials and keep the number, e.g. ew1.
+var regionCatalogs = map[string]map[string]regionCodes{
+	"aws": {
+		"af-south-1":     {fixed: "afs1", short: "fs1"},
+		"ap-east-1":      {fixed: "ape1", short: "ae1"},
+		"ap-northeast-1": {fixed: "apn1", short: "an1"},
+		"ap-northeast-2": {fixed: "apn2", short: "an2"},
+		"ap-northeast-3": {fixed: "apn3", short: "an3"},
+		"ap-south-1":     {fixed: "aps1", short: "as0"},
+		"ap-southeast-1": {fixed: "ase1", short: "as1"},
+		"ap-southeast-2": {fixed: "ase2", short: "as2"},
+		"ap-southeast-3": {fixed: "ase3", short: "as3"},
+		"ap-southeast-4": {fixed: "ase4", short: "as4"},
+		"ca-central-1":   {fixed: "cac1", short: "cc1"},
+		"ca-west-1":      {fixed: "caw1", short: "cw1"},
+		"cn-north-1":     {fixed: "cnn1", short: "nn0"},
+		"cn-northwest-1": {fixed: "cnw1", short: "nn1"},
+		"eu-central-1":   {fixed: "euc1", short: "ec1"},
+		"eu-central-2":   {fixed: "euc2", short: "ec2"},
+		"eu-north-1":     {fixed: "eun1", short: "en1"},
+		"eu-south-1":     {fixed: "eus1", short: "es1"},
+		"eu-south-2":     {fixed: "eus2", short: "es2"},
+		"eu-west-1":      {fixed: "euw1", short: "ew1"},
+		"eu-west-2":      {fixed: "euw2", short: "ew2"},
+		"eu-west-3":      {fixed: "euw3", short: "ew3"},
+		"il-central-1":   {fixed: "ilc1", short: "ic1"},
+		"me-central-1":   {fixed: "mec1", short: "mc1"},
+		"me-south-1":     {fixed: "mes1", short: "ms1"},
+		"sa-east-1":      {fixed: "sae1", short: "se1"},
+		"us-east-1":      {fixed: "use1", short: "ue1"},
+		"us-east-2":      {fixed: "use2", short: "ue2"},
+		"us-gov-east-1":  {fixed: "uge1", short: "ge1"},
+		"us-gov-west-1":  {fixed: "ugw1", short: "gw1"},
+		"us-west-1":      {fixed: "usw1", short: "uw1"},
+		"us-west-2":      {fixed: "usw2", short: "uw2"},
+	},
+	"azure": {
+		"australiacentral":   {fixed: "auce1", short: "acl"},
+		"australiaeast":      {fixed: "auea1", short: "ae"},
+		"australiasoutheast": {fixed: "ause1", short: "ase"},
+		"brazilsouth":        {fixed: "brso1", short: "brs"},
+		"canadacentral":      {fixed: "cace1", short: "cnc"},
+		"canadaeast":         {fixed: "caea1", short: "cne"},
+		"centralindia":       {fixed: "ince1", short: "inc"},
+		"centralus":          {fixed: "usce1", short: "cus"},
+		"eastasia":           {fixed: "hkea1", short: "ea"},
+		"eastus":             {fixed: "usea1", short: "eus"},
+		"eastus2":            {fixed: "usea2", short: "eus2"},
+		"francecentral":      {fixed: "frce1", short: "frc"},
+		"germanywestcentral": {fixed: "dewc1", short: "gwc"},
+		"israelcentral":      {fixed: "ilce1", short: "ilc"},
+		"italynorth":         {fixed: "itno1", short: "itn"},
+		"japaneast":          {fixed: "jpea1", short: "jpe"},
+		"japanwest":          {fixed: "jpwe1", short: "jpw"},
+		"koreacentral":       {fixed: "krce1", short: "krc"},
+		"koreasouth":         {fixed: "krso1", short: "krs"},
+		"mexicocentral":      {fixed: "mxce1", short: "mxc"},
+		"northcentralus":     {fixed: "usnc1", short: "ncus"},
+		"northeurope":        {fixed: "ieno1", short: "ne"},
+		"norwayeast":         {fixed: "noea1", short: "nwe"},
+		"polandcentral":      {fixed: "plce1", short: "plc"},
+		"qatarcentral":       {fixed: "qace1", short: "qac"},
+		"southafricanorth":   {fixed: "zano1", short: "san"},
+		"southcentralus":     {fixed: "ussc1", short: "scus"},
+		"southeastasia":      {fixed: "sgse1", short: "sea"},
+		"southindia":         {fixed: "inso1", short: "ins"},
+		"spaincentral":       {fixed: "esce1", short: "spc"},
+		"swedencentral":      {fixed: "sece1", short: "sdc"},
+		"switzerlandnorth":   {fixed: "chno1", short: "szn"},
+		"uaenorth":           {fixed: "aeno1", short: "uan"},
+		"uksouth":            {fixed: "gbso1", short: "uks"},
+		"ukwest":             {fixed: "gbwe1", short: "ukw"},
+		"westcentralus":      {fixed: "uswc1", short: "wcus"},
+		"westeurope":         {fixed: "nlwe1", short: "we"},
+		"westindia":          {fixed: "inwe1", short: "inw"},
+		"westus":             {fixed: "uswe1", short: "wus"},
+		"westus2":            {fixed: "uswe2", short: "wus2"},
+		"westus3":            {fixed: "uswe3", short: "wus3"},
+	},
+	"gcp": {
+		"africa-south1":           {fixed: "afso01", short: "afs1"},
+		"asia-east1":              {fixed: "asea01", short: "ae1"},
+		"asia-east2":              {fixed: "asea02", short: "ae2"},
+		"asia-northeast1":         {fixed: "asne01", short: "ane1"},
+		"asia-northeast2":         {fixed: "asne02", short: "ane2"},
+		"asia-northeast3":         {fixed: "asne03", short: "ane3"},
+		"asia-south1":             {fixed: "asso01", short: "as1"},
+		"asia-south2":             {fixed: "asso02", short: "as2"},
+		"asia-southeast1":         {fixed: "asse01", short: "ase1"},
+		"asia-southeast2":         {fixed: "asse02", short: "ase2"},
+		"australia-southeast1":    {fixed: "ause01", short: "ause1"},
+		"australia-southeast2":    {fixed: "ause02", short: "ause2"},
+		"europe-central2":         {fixed: "euce02", short: "ec2"},
+		"europe-north1":           {fixed: "euno01", short: "en1"},
+		"europe-southwest1":       {fixed: "eusw01", short: "esw1"},
+		"europe-west1":            {fixed: "euwe01", short: "ew1"},
+		"europe-west2":            {fixed: "euwe02", short: "ew2"},
+		"europe-west3":            {fixed: "euwe03", short: "ew3"},
+		"europe-west4":            {fixed: "euwe04", short: "ew4"},
+		"europe-west6":            {fixed: "euwe06", short: "ew6"},
+		"europe-west8":            {fixed: "euwe08", short: "ew8"},
+		"europe-west9":            {fixed: "euwe09", short: "ew9"},
+		"europe-west10":           {fixed: "euwe10", short: "ew10"},
+		"europe-west12":           {fixed: "euwe12", short: "ew12"},
+		"me-central1":             {fixed: "mece01", short: "mec1"},
+		"me-central2":             {fixed: "mece02", short: "mec2"},
+		"me-west1":                {fixed: "mewe01", short: "mew1"},
+		"northamerica-northeast1": {fixed: "nane01", short: "nane1"},
+		"northamerica-northeast2": {fixed: "nane02", short: "nane2"},
+		"southamerica-east1":      {fixed: "saea01", short: "sae1"},
+		"southamerica-west1":      {fixed: "sawe01", short: "saw1"},
+		"us-central1":             {fixed: "usce01", short: "uc1"},
+		"us-east1":                {fixed: "usea01", short: "ue1"},
+		"us-east4":                {fixed: "usea04", short: "ue4"},
+		"us-east5":                {fixed: "usea05", short: "ue5"},
+		"us-south1":               {fixed: "usso01", short: "us1"},
+		"us-west1":                {fixed: "uswe01", short: "uw1"},
+		"us-west2":                {fixed: "uswe02", short: "uw2"},
+		"us-west3":                {fixed: "uswe03", short: "uw3"},
+		"us-west4":                {fixed: "uswe04", short: "uw4"},
+	},
+}
+
+// builtinLookups are the lookups built from the region catalogs, keyed by name, e.g. aws_region_short.
+var builtinLookups = newBuiltinLookups()
+
+// getRegionLookupName returns the name of the built-in lookup of the regions of a cloud in a style.
+func getRegionLookupName(cloud string, style string) string {
+	return fmt.Sprintf("%s_region_%s", cloud, style)
+}
+
+// newBuiltinLookups builds a lookup for each cloud and style of the region catalogs.
+func newBuiltinLookups() map[string]Lookup {
+	lookups := make(map[string]Lookup, len(regionCatalogs)*len(RegionStyles))
+	for cloud, regions := range regionCatalogs {
+		fixed := make(map[string]string, len(regions))
+		short := make(map[string]string, len(regions))
+		for region, codes := range regions {
+			fixed[region] = codes.fixed
+			short[region] = codes.short
+		}
+		lookups[getRegionLookupName(cloud, RegionStyleFixed)] = Lookup{Values: fixed}
+		lookups[getRegionLookupName(cloud, RegionStyleShort)] = Lookup{Values: short}
+	}
+	return lookups
+}
+
+// GetBuiltinLookupNames returns the names of the built-in lookups, sorted alphabetically.
+func GetBuiltinLookupNames() []string {
+	names := make([]string, 0, len(builtinLookups))
+	for name := range builtinLookups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,144 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltinLookupNames(t *testing.T) {
+	assert.Equal(t, []string{
+		"aws_region_fixed", "aws_region_short",
+		"azure_region_fixed", "azure_region_short",
+		"gcp_region_fixed", "gcp_region_short",
+	}, GetBuiltinLookupNames())
+}
+
+func TestRegionCatalogsAreUnambiguous(t *testing.T) {
+	for cloud, regions := range regionCatalogs {
+		fixed := map[string]string{}
+		short := map[string]string{}
+		length := 0
+		for region, codes := range regions {
+			assert.NotContains(t, fixed, codes.fixed, "%s: fixed code %s of %s is not unique", cloud, codes.fixed, region)
+			assert.NotContains(t, short, codes.short, "%s: short code %s of %s is not unique", cloud, codes.short, region)
+			fixed[codes.fixed] = region
+			short[codes.short] = region
+
+			// Fixed codes have the same length within a cloud
+			if length == 0 {
+				length = len(codes.fixed)
+			}
+			assert.Len(t, codes.fixed, length, "%s: fixed code of %s", cloud, region)
+		}
+	}
+}
+
+// azureDirections are the directions of Azure region names, longest first, with their abbreviation in fixed codes.
+var azureDirections = [][2]string{
+	{"northcentral", "nc"}, {"southcentral", "sc"}, {"westcentral", "wc"}, {"southeast", "se"},
+	{"central", "ce"}, {"north", "no"}, {"south", "so"}, {"east", "ea"}, {"west", "we"},
+}
+
+func TestAzureFixedCodesFollowRegionNames(t *testing.T) {
+	for region, codes := range regionCatalogs["azure"] {
+		name := strings.TrimRight(region, "0123456789")
+		number := strings.TrimPrefix(region, name)
+		if number == "" {
+			number = "1"
+		}
+
+		// The direction is a suffix of the name, e.g. uksouth, or else a prefix, e.g. westus
+		direction := ""
+		for _, suffix := range []bool{true, false} {
+			for _, d := range azureDirections {
+				if direction == "" && (suffix && strings.HasSuffix(name, d[0]) || !suffix && strings.HasPrefix(name, d[0])) {
+					direction = d[1]
+				}
+			}
+		}
+
+		assert.Regexp(t, "^[a-z]{2}$", codes.fixed[:2], region)
+		assert.Equal(t, direction+number, codes.fixed[2:], region)
+	}
+}
+
+// gcpGeographies are the geographies of GCP region names with their abbreviation in fixed and short codes.
+var gcpGeographies = map[string][2]string{
+	"africa": {"af", "af"}, "asia": {"as", "a"}, "australia": {"au", "au"}, "europe": {"eu", "e"}, "me": {"me", "me"},
+	"northamerica": {"na", "na"}, "southamerica": {"sa", "sa"}, "us": {"us", "u"},
+}
+
+// gcpDirections are the directions of GCP region names with their abbreviation in fixed and short codes.
+var gcpDirections = map[string][2]string{
+	"central": {"ce", "c"}, "east": {"ea", "e"}, "north": {"no", "n"}, "south": {"so", "s"}, "west": {"we", "w"},
+	"northeast": {"ne", "ne"}, "southeast": {"se", "se"}, "southwest": {"sw", "sw"},
+}
+
+func TestGCPCodesFollowRegionNames(t *testing.T) {
+	for region, codes := range regionCatalogs["gcp"] {
+		geography, rest, found := strings.Cut(region, "-")
+		assert.True(t, found, region)
+		direction := strings.TrimRight(rest, "0123456789")
+		number, err := strconv.Atoi(strings.TrimPrefix(rest, direction))
+		assert.NoError(t, err, region)
+
+		g, ok := gcpGeographies[geography]
+		assert.True(t, ok, "%s: unknown geography %s", region, geography)
+		d, ok := gcpDirections[direction]
+		assert.True(t, ok, "%s: unknown direction %s", region, direction)
+
+		assert.Equal(t, fmt.Sprintf("%s%s%02d", g[0], d[0], number), codes.fixed, region)
+		assert.Equal(t, fmt.Sprintf("%s%s%d", g[1], d[1], number), codes.short, region)
+	}
+}
+
+func TestGetLabelWithBuiltinLookup(t *testing.T) {
+	properties := []Property{
+		*NewProperty("namespace", WithOrder(1)),
+		*NewProperty("region", WithOrder(2), WithLabelLookup("aws_region_short")),
+	}
+	c, err := NewProviderConfig(properties, []string{}, map[string]string{"namespace": "cp", "region": "us-west-2"})
+	assert.NoError(t, err)
+
+	label, errs := c.GetDelimitedLabel(nil, nil, nil, nil, nil, 0, true)
+	assert.Empty(t, errs)
+	assert.Equal(t, "cp-uw2", label)
+
+	label, errs = c.GetDelimitedLabel(nil, nil, nil, map[string]string{"region": "eu-central-1"}, nil, 0, true)
+	assert.Empty(t, errs)
+	assert.Equal(t, "cp-ec1", label)
+
+	label, errs = c.GetDelimitedLabel(nil, nil, nil, map[string]string{"region": "cn-north-1"}, nil, 0, true)
+	assert.Empty(t, errs)
+	assert.Equal(t, "cp-nn0", label)
+}
+
+func TestGetLookupPrefersContext(t *testing.T) {
+	c, err := NewProviderConfig([]Property{}, []string{}, map[string]string{},
+		WithLookups(map[string]Lookup{"aws_region_short": {Values: map[string]string{"us-west-2": "oregon"}, Strict: true}}))
+	assert.NoError(t, err)
+
+	lookup, err := c.GetLookup("aws_region_short")
+	assert.NoError(t, err)
+	assert.Equal(t, Lookup{Values: map[string]string{"us-west-2": "oregon"}, Strict: true}, lookup)
+
+	lookup, err = c.GetLookup("aws_region_fixed")
+	assert.NoError(t, err)
+	assert.Equal(t, "cnw1", lookup.Values["cn-northwest-1"])
+
+	lookup, err = c.GetLookup("gcp_region_fixed")
+	assert.NoError(t, err)
+	assert.Equal(t, "usce01", lookup.Values["us-central1"])
+
+	lookup, err = c.GetLookup("azure_region_short")
+	assert.NoError(t, err)
+	assert.Equal(t, "gwc", lookup.Values["germanywestcentral"])
+	assert.False(t, lookup.Strict)
+
+	_, err = c.GetLookup("aws_regions")
+	assert.ErrorIs(t, err, ErrUnknownLookup)
+}
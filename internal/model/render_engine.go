@@ -0,0 +1,264 @@
+package model
+
+import (
+	"container/list"
+	"encoding/json"
+	"maps"
+	"regexp"
+	"slices"
+	"sync"
+	"text/template"
+
+	"github.com/cloudposse/terraform-provider-context/pkg/cases"
+)
+
+// compiledRegex is a regex compiled once, along with the error if it does not compile.
+type compiledRegex struct {
+	regex *regexp.Regexp
+	err   error
+}
+
+// compiledTemplate is a label template parsed once, along with the error if it does not parse.
+type compiledTemplate struct {
+	template *template.Template
+	err      error
+}
+
+// renderKey holds every input of a rendered label or tags, so equal keys always render the same output.
+type renderKey struct {
+	Kind              string
+	Delimiter         *string
+	Properties        []string
+	PropertyOrder     []string
+	Template          string
+	Values            map[string]string
+	ReplaceCharsRegex *string
+	MaxLength         int
+	Truncate          bool
+	TagsKeyCase       string
+	TagsValueCase     string
+}
+
+// getCaseKey returns the name of the case for a render key, or an empty string when the case is not set.
+func getCaseKey(c *cases.Case) string {
+	if c == nil {
+		return ""
+	}
+	return c.String()
+}
+
+// renderResult is a rendered label or tags. Cached results are shared between callers, so render returns copies.
+type renderResult struct {
+	label       string
+	tags        map[string]string
+	explanation *Explanation
+	errs        []error
+}
+
+// renderEngine compiles the regexes and templates of a context and caches the labels and tags rendered from it. The
+// validation regexes, required_when patterns, replace_chars_regex and label format templates of the context are
+// compiled when the context is created and never change afterwards. Regexes and templates passed in by labels, and
+// rendered outputs, are cached on first use, keeping the most recently used ones. Terraform reads data sources in
+// parallel, so the engine is safe for concurrent use.
+type renderEngine struct {
+	regexes   map[string]compiledRegex
+	templates map[string]compiledTemplate
+
+	regexCache    *lruCache[compiledRegex]
+	templateCache *lruCache[compiledTemplate]
+	ruleCache     *lruCache[compiledValidationRule]
+	renderCache   *lruCache[renderResult]
+}
+
+const (
+	// compileCacheSize is the number of regexes, templates and validation rules passed in by labels that are kept.
+	compileCacheSize = 1024
+	// renderCacheSize is the number of rendered labels and tags that are kept.
+	renderCacheSize = 16384
+)
+
+// renderEngineOption is an option of newRenderEngine.
+type renderEngineOption func(*renderEngine)
+
+// withoutCaching makes the engine compile and render on every call, like the provider did before the engine. It is used
+// to compare against in benchmarks.
+func withoutCaching() renderEngineOption {
+	return func(e *renderEngine) {
+		e.regexes = map[string]compiledRegex{}
+		e.templates = map[string]compiledTemplate{}
+		e.regexCache = nil
+		e.templateCache = nil
+		e.ruleCache = nil
+		e.renderCache = nil
+	}
+}
+
+// newRenderEngine compiles the regexes and templates of the context.
+func newRenderEngine(c *ProviderConfig, opts ...renderEngineOption) *renderEngine {
+	e := &renderEngine{
+		regexes:       map[string]compiledRegex{},
+		templates:     map[string]compiledTemplate{},
+		regexCache:    newLRUCache[compiledRegex](compileCacheSize),
+		templateCache: newLRUCache[compiledTemplate](compileCacheSize),
+		ruleCache:     newLRUCache[compiledValidationRule](compileCacheSize),
+		renderCache:   newLRUCache[renderResult](renderCacheSize),
+	}
+
+	for _, p := range c.properties {
+		if p.ValidationRegex != "" {
+			e.regexes[p.ValidationRegex] = compileRegex(p.ValidationRegex)
+		}
+		for _, patterns := range p.RequiredWhen {
+			for _, pattern := range patterns {
+				regex := getRequiredWhenRegex(pattern)
+				e.regexes[regex] = compileRegex(regex)
+			}
+		}
+	}
+	if c.replaceCharsRegex != "" {
+		e.regexes[c.replaceCharsRegex] = compileRegex(c.replaceCharsRegex)
+	}
+	for _, format := range c.labelFormats {
+		if format.Template != nil {
+			e.templates[*format.Template] = parseTemplate(*format.Template)
+		}
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// compileRegex compiles a regex, keeping the error if it does not compile.
+func compileRegex(regex string) compiledRegex {
+	r, err := regexp.Compile(regex)
+	return compiledRegex{regex: r, err: err}
+}
+
+// parseTemplate parses a label template, keeping the error if it does not parse.
+func parseTemplate(templateString string) compiledTemplate {
+	tmpl, err := template.New("label").Parse(templateString)
+	return compiledTemplate{template: tmpl, err: err}
+}
+
+// getRegex returns the compiled regex.
+func (e *renderEngine) getRegex(regex string) (*regexp.Regexp, error) {
+	compiled, ok := e.regexes[regex]
+	if !ok {
+		compiled = e.regexCache.getOrCompute(regex, func() compiledRegex { return compileRegex(regex) })
+	}
+	return compiled.regex, compiled.err
+}
+
+// getRedactRegex returns the compiled regex used to redact labels. An empty regex returns nil.
+func (e *renderEngine) getRedactRegex(regex string) (*regexp.Regexp, error) {
+	if regex == "" {
+		return nil, nil //nolint:nilnil
+	}
+	return e.getRegex(regex)
+}
+
+// getTemplate returns the parsed label template.
+func (e *renderEngine) getTemplate(templateString string) (*template.Template, error) {
+	compiled, ok := e.templates[templateString]
+	if !ok {
+		compiled = e.templateCache.getOrCompute(templateString, func() compiledTemplate { return parseTemplate(templateString) })
+	}
+	return compiled.template, compiled.err
+}
+
+// getValidationRule returns the validation rule with its templates parsed. Rules are checked when the context is
+// created and parsed on first use.
+func (e *renderEngine) getValidationRule(rule ValidationRule) compiledValidationRule {
+	compile := func() compiledValidationRule {
+		compiled, err := rule.compile()
+		compiled.err = err
+		return compiled
+	}
+
+	key, err := json.Marshal(rule)
+	if err != nil {
+		return compile()
+	}
+	return e.ruleCache.getOrCompute(string(key), compile)
+}
+
+// render returns the output cached for the inputs or renders and caches it. The tags, explanation and errors are
+// copied, so callers may modify them without changing the cached result.
+func (e *renderEngine) render(key renderKey, render func() renderResult) renderResult {
+	encodedKey, err := json.Marshal(key)
+	if err != nil {
+		return render()
+	}
+
+	result := e.renderCache.getOrCompute(string(encodedKey), render)
+	result.tags = maps.Clone(result.tags)
+	result.explanation = result.explanation.clone()
+	result.errs = slices.Clone(result.errs)
+	return result
+}
+
+// lruCache is a cache that is safe for concurrent use and keeps the most recently used entries, up to its size. A nil
+// cache keeps nothing and computes every value.
+type lruCache[V any] struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+// lruEntry is an entry of an lruCache, stored in its order.
+type lruEntry[V any] struct {
+	key   string
+	value V
+}
+
+// newLRUCache returns an empty cache that keeps up to size entries.
+func newLRUCache[V any](size int) *lruCache[V] {
+	return &lruCache[V]{
+		size:    size,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// getOrCompute returns the value cached for the key or computes and caches it, evicting the least recently used entry
+// when the cache is full. Values are computed without holding the lock, so concurrent callers may compute the same
+// value, in which case the first one stored is kept.
+func (c *lruCache[V]) getOrCompute(key string, compute func() V) V {
+	if c == nil {
+		return compute()
+	}
+	if v, ok := c.get(key); ok {
+		return v
+	}
+	v := compute()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.order.MoveToFront(element)
+		return element.Value.(*lruEntry[V]).value //nolint:forcetypeassert
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry[V]{key: key, value: v})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry[V]).key) //nolint:forcetypeassert
+	}
+	return v
+}
+
+// get returns the value cached for the key and marks it as the most recently used.
+func (c *lruCache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*lruEntry[V]).value, true //nolint:forcetypeassert
+}
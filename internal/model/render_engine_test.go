@@ -0,0 +1,264 @@
+package model
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getEngineProviderConfig(t testing.TB) *ProviderConfig {
+	properties := []Property{
+		*NewProperty("namespace", WithOrder(1), WithValidationRegex("^[a-z0-9]+$")),
+		*NewProperty("stage", WithOrder(2), WithValidationRegex("^[a-z]+$")),
+		*NewProperty("name", WithOrder(3), WithValidationRegex("^[a-z0-9-]+$")),
+	}
+	template := "{{.namespace}}_{{.stage}}_{{.name}}"
+	c, err := NewProviderConfig(properties, []string{}, map[string]string{"namespace": "cp", "stage": "dev"},
+		WithReplaceCharsRegex("[^a-z0-9_-]"),
+		WithLabelFormats(map[string]LabelFormat{"id": {Template: &template}}))
+	assert.NoError(t, err)
+	return c
+}
+
+func TestRenderEngineCompilesContextOnce(t *testing.T) {
+	c := getEngineProviderConfig(t)
+
+	assert.Contains(t, c.engine.regexes, "^[a-z0-9]+$")
+	assert.Contains(t, c.engine.regexes, "[^a-z0-9_-]")
+	assert.Contains(t, c.engine.templates, "{{.namespace}}_{{.stage}}_{{.name}}")
+
+	r1, err := c.engine.getRegex("^[a-z]+$")
+	assert.NoError(t, err)
+	r2, err := c.engine.getRegex("^[a-z]+$")
+	assert.NoError(t, err)
+	assert.Same(t, r1, r2)
+}
+
+func TestRenderEngineCachesRegexesAndTemplatesOfLabels(t *testing.T) {
+	c := getEngineProviderConfig(t)
+
+	r1, err := c.engine.getRegex("[0-9]")
+	assert.NoError(t, err)
+	r2, err := c.engine.getRegex("[0-9]")
+	assert.NoError(t, err)
+	assert.Same(t, r1, r2)
+
+	t1, err := c.engine.getTemplate("{{.stage}}")
+	assert.NoError(t, err)
+	t2, err := c.engine.getTemplate("{{.stage}}")
+	assert.NoError(t, err)
+	assert.Same(t, t1, t2)
+
+	_, err = c.engine.getRegex("[")
+	assert.Error(t, err)
+	_, err = c.engine.getTemplate("{{.stage")
+	assert.Error(t, err)
+}
+
+func TestRenderEngineCachesLabels(t *testing.T) {
+	c := getEngineProviderConfig(t)
+
+	label1, explanation1, errs := c.ExplainDelimitedLabel(nil, nil, nil, map[string]string{"name": "app"}, nil, 0, true)
+	assert.Empty(t, errs)
+	label2, explanation2, errs := c.ExplainDelimitedLabel(nil, nil, nil, map[string]string{"name": "app"}, nil, 0, true)
+	assert.Empty(t, errs)
+	assert.Equal(t, "cp-dev-app", label1)
+	assert.Equal(t, label1, label2)
+	assert.Equal(t, explanation1, explanation2)
+	assert.NotSame(t, explanation1, explanation2)
+
+	label3, _, errs := c.ExplainDelimitedLabel(nil, nil, nil, map[string]string{"name": "web"}, nil, 0, true)
+	assert.Empty(t, errs)
+	assert.Equal(t, "cp-dev-web", label3)
+
+	// The same values with another delimiter render another label
+	delimiter := "_"
+	label4, _, errs := c.ExplainDelimitedLabel(&delimiter, nil, nil, map[string]string{"name": "app"}, nil, 0, true)
+	assert.Empty(t, errs)
+	assert.Equal(t, "cp_dev_app", label4)
+
+	label5, errs := c.GetFormattedLabel("id", map[string]string{"name": "app"})
+	assert.Empty(t, errs)
+	assert.Equal(t, "cp_dev_app", label5)
+}
+
+func TestRenderEngineCachesValidationErrors(t *testing.T) {
+	c := getEngineProviderConfig(t)
+
+	for range 2 {
+		_, errs := c.GetDelimitedLabel(nil, nil, nil, map[string]string{"name": "App"}, nil, 0, true)
+		assert.Len(t, errs, 1)
+		assert.ErrorIs(t, errs[0], ErrRegexMismatch)
+	}
+}
+
+func TestRenderEngineCachedTagsAreCopied(t *testing.T) {
+	c := getEngineProviderConfig(t)
+
+	tags, errs := c.GetTags(map[string]string{"name": "app"}, nil, nil)
+	assert.Empty(t, errs)
+	tags["Extra"] = "value"
+
+	tags, errs = c.GetTags(map[string]string{"name": "app"}, nil, nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]string{"Namespace": "cp", "Stage": "dev", "Name": "app"}, tags)
+}
+
+func TestRenderEngineCachedResultsAreCopied(t *testing.T) {
+	c := getEngineProviderConfig(t)
+
+	_, explanation, errs := c.ExplainDelimitedLabel(nil, nil, nil, map[string]string{"name": "app"}, nil, 0, true)
+	assert.Empty(t, errs)
+	explanation.Properties[0].Value = "changed"
+	explanation.Properties = append(explanation.Properties, PropertyExplanation{Name: "extra"})
+	explanation.Untruncated = "changed"
+
+	_, explanation, errs = c.ExplainDelimitedLabel(nil, nil, nil, map[string]string{"name": "app"}, nil, 0, true)
+	assert.Empty(t, errs)
+	assert.Len(t, explanation.Properties, 3)
+	assert.Equal(t, "cp", explanation.Properties[0].Value)
+	assert.Equal(t, "cp-dev-app", explanation.Untruncated)
+
+	_, errs = c.GetDelimitedLabel(nil, nil, nil, map[string]string{"name": "App"}, nil, 0, true)
+	assert.Len(t, errs, 1)
+	errs[0] = nil
+
+	_, errs = c.GetDelimitedLabel(nil, nil, nil, map[string]string{"name": "App"}, nil, 0, true)
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrRegexMismatch)
+}
+
+func TestRenderEngineEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newLRUCache[int](2)
+	computed := 0
+	compute := func(v int) func() int {
+		return func() int {
+			computed++
+			return v
+		}
+	}
+
+	assert.Equal(t, 1, cache.getOrCompute("a", compute(1)))
+	assert.Equal(t, 2, cache.getOrCompute("b", compute(2)))
+	assert.Equal(t, 1, cache.getOrCompute("a", compute(10)))
+	assert.Equal(t, 3, cache.getOrCompute("c", compute(3)))
+	assert.Equal(t, 2, cache.order.Len())
+	assert.Equal(t, 3, computed)
+
+	// b was the least recently used entry, so it was evicted and is computed again
+	assert.Equal(t, 20, cache.getOrCompute("b", compute(20)))
+	assert.Equal(t, 3, cache.getOrCompute("c", compute(30)))
+	assert.Equal(t, 4, computed)
+
+	var uncached *lruCache[int]
+	assert.Equal(t, 5, uncached.getOrCompute("a", compute(5)))
+	assert.Equal(t, 6, uncached.getOrCompute("a", compute(6)))
+}
+
+func TestRenderEngineWithoutCaching(t *testing.T) {
+	c := getEngineProviderConfig(t)
+	c.engine = newRenderEngine(c, withoutCaching())
+	assert.Empty(t, c.engine.regexes)
+
+	r1, err := c.engine.getRegex("^[a-z]+$")
+	assert.NoError(t, err)
+	r2, err := c.engine.getRegex("^[a-z]+$")
+	assert.NoError(t, err)
+	assert.NotSame(t, r1, r2)
+
+	label, errs := c.GetDelimitedLabel(nil, nil, nil, map[string]string{"name": "app"}, nil, 0, true)
+	assert.Empty(t, errs)
+	assert.Equal(t, "cp-dev-app", label)
+}
+
+func TestRenderEngineConcurrentRendering(t *testing.T) {
+	c := getEngineProviderConfig(t)
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := fmt.Sprintf("app%d", i%5)
+			label, errs := c.GetTemplatedLabel("{{.namespace}}/{{.name}}", map[string]string{"name": name}, nil, 0, true)
+			assert.Empty(t, errs)
+			assert.Equal(t, "cp"+name, label)
+			tags, errs := c.GetTags(map[string]string{"name": name}, nil, nil)
+			assert.Empty(t, errs)
+			assert.Equal(t, name, tags["Name"])
+		}()
+	}
+	wg.Wait()
+}
+
+// getBenchmarkValues returns the values of a workload of 5,000 distinct labels.
+func getBenchmarkValues() []map[string]string {
+	values := make([]map[string]string, 5000)
+	for i := range values {
+		values[i] = map[string]string{"name": fmt.Sprintf("app-%d", i)}
+	}
+	return values
+}
+
+// renderBenchmarkLabels renders a delimited and a templated label for each of the values. It reports errors with
+// b.Error rather than b.Fatal, since it also runs in the goroutines of RunParallel.
+func renderBenchmarkLabels(b *testing.B, c *ProviderConfig, values []map[string]string) bool {
+	b.Helper()
+	for _, v := range values {
+		if _, errs := c.GetDelimitedLabel(nil, nil, nil, v, nil, 0, true); len(errs) > 0 {
+			b.Error(errs)
+			return false
+		}
+		if _, errs := c.GetFormattedLabel("id", v); len(errs) > 0 {
+			b.Error(errs)
+			return false
+		}
+	}
+	return true
+}
+
+// BenchmarkRender5000Labels renders 5,000 delimited and 5,000 templated labels. Uncached compiles every regex and
+// template on every call, precompiled renders every label for the first time and cached renders labels that were
+// rendered before.
+func BenchmarkRender5000Labels(b *testing.B) {
+	values := getBenchmarkValues()
+
+	b.Run("uncached", func(b *testing.B) {
+		c := getEngineProviderConfig(b)
+		c.engine = newRenderEngine(c, withoutCaching())
+		for b.Loop() {
+			renderBenchmarkLabels(b, c, values)
+		}
+	})
+
+	b.Run("precompiled", func(b *testing.B) {
+		for b.Loop() {
+			b.StopTimer()
+			c := getEngineProviderConfig(b)
+			b.StartTimer()
+			renderBenchmarkLabels(b, c, values)
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		c := getEngineProviderConfig(b)
+		renderBenchmarkLabels(b, c, values)
+		for b.Loop() {
+			renderBenchmarkLabels(b, c, values)
+		}
+	})
+
+	b.Run("cached_parallel", func(b *testing.B) {
+		c := getEngineProviderConfig(b)
+		renderBenchmarkLabels(b, c, values)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if !renderBenchmarkLabels(b, c, values) {
+					return
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// WithRequiredWhen makes the property required when every property in the conditions has a value that matches one of
+// its patterns, e.g. {"namespace": {"acme", "globex"}}. Each pattern is a regex that must match the whole value, so a
+// plain value only matches itself.
+func WithRequiredWhen(conditions map[string][]string) func(*Property) {
+	return func(obj *Property) {
+		obj.RequiredWhen = conditions
+	}
+}
+
+// getRequiredWhenRegex returns the regex that matches a whole value against a required_when pattern.
+func getRequiredWhenRegex(pattern string) string {
+	return "^(?:" + pattern + ")$"
+}
+
+// getRequiredWhenProperties returns the names of the properties in the required_when conditions, sorted.
+func (p *Property) getRequiredWhenProperties() []string {
+	names := make([]string, 0, len(p.RequiredWhen))
+	for name := range p.RequiredWhen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateRequiredWhen checks that the property has a value when its required_when conditions hold for the values.
+// Properties in the unknown list are not known yet, so conditions on them are not checked.
+func (p *Property) validateRequiredWhen(values map[string]string, unknown []string, getRegex func(string) (*regexp.Regexp, error)) *PropertyError {
+	if len(p.RequiredWhen) == 0 || p.Required || strings.TrimSpace(values[p.Name]) != "" {
+		return nil
+	}
+
+	reasons := []string{}
+	for _, name := range p.getRequiredWhenProperties() {
+		if slices.Contains(unknown, name) {
+			return nil
+		}
+		matched, err := p.matchesRequiredWhen(p.RequiredWhen[name], values[name], getRegex)
+		if err != nil {
+			return p.withSeverity(CheckRequired, &PropertyError{Property: p.Name, Value: values[p.Name], Err: err})
+		}
+		if !matched {
+			return nil
+		}
+		reasons = append(reasons, fmt.Sprintf("%s is %s (one of: %s)", name, values[name], strings.Join(p.RequiredWhen[name], ", ")))
+	}
+
+	err := fmt.Errorf("%w: value for property %s when %s", ErrPropertyRequired, p.Name, strings.Join(reasons, " and "))
+	return p.withSeverity(CheckRequired, &PropertyError{Property: p.Name, Value: values[p.Name], Err: err})
+}
+
+// matchesRequiredWhen reports whether the value matches one of the patterns. An empty value never matches.
+func (p *Property) matchesRequiredWhen(patterns []string, value string, getRegex func(string) (*regexp.Regexp, error)) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+
+	for _, pattern := range patterns {
+		r, err := getRegex(getRequiredWhenRegex(pattern))
+		if err != nil {
+			return false, invalidRequiredWhenRegexError(pattern, p.Name)
+		}
+		if r.MatchString(value) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// invalidRequiredWhenRegexError returns the error for a required_when pattern of a property that does not compile.
+func invalidRequiredWhenRegexError(pattern string, propertyName string) error {
+	return fmt.Errorf("%w: %s in required_when for property %s", ErrInvalidRegex, pattern, propertyName)
+}
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getRequiredWhenProviderConfig(t *testing.T) *ProviderConfig {
+	t.Helper()
+	properties := []Property{
+		*NewProperty("namespace"),
+		*NewProperty("stage"),
+		*NewProperty("tenant", WithRequiredWhen(map[string][]string{"namespace": {"acme", "globex"}})),
+		*NewProperty("owner", WithRequiredWhen(map[string][]string{"namespace": {"acme"}, "stage": {"prod.*"}})),
+	}
+	c, err := NewProviderConfig(properties, []string{}, map[string]string{})
+	assert.NoError(t, err)
+	return c
+}
+
+func TestValidatePropertiesRequiredWhen(t *testing.T) {
+	c := getRequiredWhenProviderConfig(t)
+
+	testCases := map[string]struct {
+		values   map[string]string
+		expected []string
+	}{
+		"condition does not hold": {
+			values: map[string]string{"namespace": "cp", "stage": "prod"},
+		},
+		"condition holds and value is set": {
+			values: map[string]string{"namespace": "globex", "tenant": "core"},
+		},
+		"condition holds": {
+			values:   map[string]string{"namespace": "globex"},
+			expected: []string{"property is required: value for property tenant when namespace is globex (one of: acme, globex)"},
+		},
+		"patterns match the whole value": {
+			values: map[string]string{"namespace": "acme-corp", "stage": "production"},
+		},
+		"every condition must hold": {
+			values:   map[string]string{"namespace": "acme", "stage": "dev", "tenant": "core"},
+			expected: []string{},
+		},
+		"every condition holds": {
+			values:   map[string]string{"namespace": "acme", "stage": "production", "tenant": "core"},
+			expected: []string{"property is required: value for property owner when namespace is acme (one of: acme) and stage is production (one of: prod.*)"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			errs := c.ValidateProperties(tc.values)
+			messages := []string{}
+			for _, err := range errs {
+				assert.ErrorIs(t, err, ErrPropertyRequired)
+				messages = append(messages, err.Error())
+			}
+			assert.ElementsMatch(t, tc.expected, messages)
+		})
+	}
+}
+
+func TestValidatePropertiesRequiredWhenError(t *testing.T) {
+	c := getRequiredWhenProviderConfig(t)
+
+	errs := c.ValidateProperties(map[string]string{"namespace": "acme"})
+	assert.Len(t, errs, 1)
+	var propertyErr *PropertyError
+	assert.ErrorAs(t, errs[0], &propertyErr)
+	assert.Equal(t, "tenant", propertyErr.Property)
+}
+
+func TestValidatePropertiesRequiredWhenInvalidRegex(t *testing.T) {
+	properties := []Property{
+		*NewProperty("namespace"),
+		*NewProperty("tenant", WithRequiredWhen(map[string][]string{"namespace": {"acme("}})),
+	}
+	c, err := NewProviderConfig(properties, []string{}, map[string]string{})
+	assert.NoError(t, err)
+
+	errs := c.ValidateProperties(map[string]string{"namespace": "acme"})
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrInvalidRegex)
+	assert.Equal(t, "regex is invalid: acme( in required_when for property tenant", errs[0].Error())
+}
+
+func TestValidateKnownPropertiesRequiredWhen(t *testing.T) {
+	c := getRequiredWhenProviderConfig(t)
+
+	// The namespace is only known during apply, so the condition is checked then
+	assert.Empty(t, c.ValidateKnownProperties(map[string]string{}, []string{"namespace"}))
+	assert.Len(t, c.ValidateKnownProperties(map[string]string{"namespace": "acme"}, []string{"stage"}), 1)
+}
+
+func TestGetTagsRequiredWhen(t *testing.T) {
+	c := getRequiredWhenProviderConfig(t)
+
+	_, errs := c.GetTags(map[string]string{"namespace": "acme"}, nil, nil)
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrPropertyRequired)
+
+	tags, errs := c.GetTags(map[string]string{"namespace": "acme", "tenant": "core"}, nil, nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, "core", tags["Tenant"])
+}
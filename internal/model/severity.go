@@ -0,0 +1,114 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrUnknownSeverity = errors.New("unknown severity")
+	ErrUnknownCheck    = errors.New("unknown check")
+)
+
+// Severity is how a failed check of a property is reported. Errors stop the label or tags from being rendered, while
+// warnings are reported alongside them, so a new convention can be rolled out before it is enforced.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// ValidSeverities are the names of the severities a check can have.
+var ValidSeverities = []string{string(SeverityError), string(SeverityWarning)}
+
+const (
+	// CheckRequired is the check of required and required_when.
+	CheckRequired = "required"
+	// CheckMinLength is the check of min_length.
+	CheckMinLength = "min_length"
+	// CheckMaxLength is the check of max_length.
+	CheckMaxLength = "max_length"
+	// CheckValidationRegex is the check of validation_regex.
+	CheckValidationRegex = "validation_regex"
+	// CheckAllowedValues is the check of allowed_values.
+	CheckAllowedValues = "allowed_values"
+)
+
+// ValidChecks are the names of the checks of a property that can have a severity.
+var ValidChecks = []string{CheckAllowedValues, CheckMaxLength, CheckMinLength, CheckRequired, CheckValidationRegex}
+
+// ParseSeverity returns the severity with the given name.
+func ParseSeverity(name string) (Severity, error) {
+	if !slices.Contains(ValidSeverities, name) {
+		return "", fmt.Errorf("%w: %q, valid severities are: %s", ErrUnknownSeverity, name, strings.Join(ValidSeverities, ", "))
+	}
+	return Severity(name), nil
+}
+
+// NewSeverities returns the severities of the checks of a property from their names, keyed by check.
+func NewSeverities(names map[string]string) (map[string]Severity, error) {
+	severities := make(map[string]Severity, len(names))
+	for check, name := range names {
+		if !slices.Contains(ValidChecks, check) {
+			return nil, fmt.Errorf("%w: %q, valid checks are: %s", ErrUnknownCheck, check, strings.Join(ValidChecks, ", "))
+		}
+		severity, err := ParseSeverity(name)
+		if err != nil {
+			return nil, fmt.Errorf("check %s: %w", check, err)
+		}
+		severities[check] = severity
+	}
+	return severities, nil
+}
+
+// WithSeverities sets the severity of the checks of the property, keyed by check. Checks without a severity are errors.
+func WithSeverities(severities map[string]Severity) func(*Property) {
+	return func(obj *Property) {
+		obj.Severities = severities
+	}
+}
+
+// getSeverity returns the severity of a check of the property.
+func (p *Property) getSeverity(check string) Severity {
+	if severity, ok := p.Severities[check]; ok {
+		return severity
+	}
+	return SeverityError
+}
+
+// ValidationResults are the failed checks of a property.
+type ValidationResults []*PropertyError
+
+// Errors returns the results as errors.
+func (r ValidationResults) Errors() []error {
+	errs := make([]error, 0, len(r))
+	for _, result := range r {
+		errs = append(errs, result)
+	}
+	return errs
+}
+
+// IsWarning reports whether the error is a failed check with the warning severity.
+func IsWarning(err error) bool {
+	var propertyErr *PropertyError
+	return errors.As(err, &propertyErr) && propertyErr.Severity == SeverityWarning
+}
+
+// HasErrors reports whether any of the errors is not a warning.
+func HasErrors(errs []error) bool {
+	return slices.ContainsFunc(errs, func(err error) bool { return err != nil && !IsWarning(err) })
+}
+
+// getWarnings returns the warnings among the errors, or nil if there are none.
+func getWarnings(errs []error) []error {
+	var warnings []error
+	for _, err := range errs {
+		if IsWarning(err) {
+			warnings = append(warnings, err)
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropertyValidateSeverity(t *testing.T) {
+	p := NewProperty("stage",
+		WithRequired(),
+		WithMinLength(3),
+		WithValidationRegex("^[a-z]+$"),
+		WithSeverities(map[string]Severity{CheckValidationRegex: SeverityWarning}),
+	)
+
+	results := p.Validate("P")
+	assert.Len(t, results, 2)
+	assert.Equal(t, CheckMinLength, results[0].Check)
+	assert.Equal(t, SeverityError, results[0].Severity)
+	assert.ErrorIs(t, results[0], ErrValueTooShort)
+	assert.Equal(t, CheckValidationRegex, results[1].Check)
+	assert.Equal(t, SeverityWarning, results[1].Severity)
+	assert.ErrorIs(t, results[1], ErrRegexMismatch)
+
+	errs := results.Errors()
+	assert.True(t, HasErrors(errs))
+	assert.False(t, IsWarning(errs[0]))
+	assert.True(t, IsWarning(errs[1]))
+	assert.False(t, HasErrors(errs[1:]))
+}
+
+func TestPropertyValidateSeverityInvalidRegex(t *testing.T) {
+	// A regex that does not compile is a mistake in the property, so it is an error whatever the severity
+	p := NewProperty("stage", WithValidationRegex("["), WithSeverities(map[string]Severity{CheckValidationRegex: SeverityWarning}))
+
+	results := p.Validate("dev")
+	assert.Len(t, results, 1)
+	assert.ErrorIs(t, results[0], ErrInvalidRegex)
+	assert.Equal(t, SeverityError, results[0].Severity)
+}
+
+func TestValidatePropertiesRequiredWhenSeverity(t *testing.T) {
+	properties := []Property{
+		*NewProperty("namespace"),
+		*NewProperty("tenant",
+			WithRequiredWhen(map[string][]string{"namespace": {"acme"}}),
+			WithSeverities(map[string]Severity{CheckRequired: SeverityWarning})),
+	}
+	c, err := NewProviderConfig(properties, []string{"namespace", "tenant"}, map[string]string{})
+	assert.NoError(t, err)
+
+	errs := c.ValidateProperties(map[string]string{"namespace": "acme"})
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrPropertyRequired)
+	assert.True(t, IsWarning(errs[0]))
+}
+
+func TestRenderWithWarnings(t *testing.T) {
+	properties := []Property{
+		*NewProperty("namespace", WithRequired()),
+		*NewProperty("stage", WithAllowedValues([]AllowedValue{{Value: "dev"}, {Value: "prod"}}),
+			WithSeverities(map[string]Severity{CheckAllowedValues: SeverityWarning})),
+	}
+	c, err := NewProviderConfig(properties, []string{"namespace", "stage"}, map[string]string{"namespace": "cp"})
+	assert.NoError(t, err)
+	values := map[string]string{"stage": "qa"}
+
+	label, errs := c.GetDelimitedLabel(nil, nil, nil, values, nil, 0, true)
+	assert.Equal(t, "cp-qa", label)
+	assert.Len(t, errs, 1)
+	assert.True(t, IsWarning(errs[0]))
+
+	label, errs = c.GetTemplatedLabel("{{.namespace}}/{{.stage}}", values, nil, 0, true)
+	assert.Equal(t, "cp/qa", label)
+	assert.Len(t, errs, 1)
+	assert.True(t, IsWarning(errs[0]))
+
+	tags, errs := c.GetTags(values, nil, nil)
+	assert.Equal(t, "qa", tags["Stage"])
+	assert.Len(t, errs, 1)
+	assert.True(t, IsWarning(errs[0]))
+
+	tagsList, errs := c.GetTagsAsList(values, nil, nil)
+	assert.Len(t, tagsList, 2)
+	assert.Len(t, errs, 1)
+
+	// Errors still stop the label from being rendered
+	label, errs = c.GetDelimitedLabel(nil, nil, nil, map[string]string{"namespace": "", "stage": "qa"}, nil, 0, true)
+	assert.Empty(t, label)
+	assert.True(t, HasErrors(errs))
+}
+
+func TestNewSeverities(t *testing.T) {
+	severities, err := NewSeverities(map[string]string{CheckRequired: "warning", CheckMaxLength: "error"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]Severity{CheckRequired: SeverityWarning, CheckMaxLength: SeverityError}, severities)
+
+	_, err = NewSeverities(map[string]string{CheckRequired: "info"})
+	assert.ErrorIs(t, err, ErrUnknownSeverity)
+
+	_, err = NewSeverities(map[string]string{"pattern": "warning"})
+	assert.ErrorIs(t, err, ErrUnknownCheck)
+}
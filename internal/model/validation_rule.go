@@ -0,0 +1,161 @@
+package model
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+var (
+	ErrInvalidValidationRule = errors.New("invalid validation rule")
+	ErrValidationRuleFailed  = errors.New("validation rule failed")
+)
+
+// ValidationRule is a constraint that spans several properties, such as a budget for the combined length of two values.
+// Condition is a template that renders "true" when the values are valid and "false" when they are not, in which case
+// ErrorMessage, which is a template too, describes the problem.
+type ValidationRule struct {
+	Name         string
+	Condition    string
+	ErrorMessage string
+}
+
+// ValidationRuleError describes a validation rule that failed or could not be evaluated. Err wraps
+// ErrValidationRuleFailed or ErrInvalidValidationRule.
+type ValidationRuleError struct {
+	Rule string
+	Err  error
+}
+
+func (e *ValidationRuleError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationRuleError) Unwrap() error {
+	return e.Err
+}
+
+// validationRuleFuncs are the functions available to validation rules in addition to the built-in template functions,
+// such as eq, and, or, not, len and le.
+var validationRuleFuncs = template.FuncMap{
+	"contains":  strings.Contains,
+	"hasPrefix": strings.HasPrefix,
+	"hasSuffix": strings.HasSuffix,
+	"matches":   regexp.MatchString,
+	"oneOf": func(value string, choices ...string) bool {
+		return slices.Contains(choices, value)
+	},
+}
+
+// compiledValidationRule is a validation rule with its templates parsed, along with the error if they do not parse.
+type compiledValidationRule struct {
+	err       error
+	name      string
+	condition *template.Template
+	message   *template.Template
+	dependsOn []string
+}
+
+// Validate checks that the rule has a name and a condition and that its templates parse.
+func (r ValidationRule) Validate() error {
+	_, err := r.compile()
+	return err
+}
+
+// compile checks the rule and parses its templates.
+func (r ValidationRule) compile() (compiledValidationRule, error) {
+	if r.Name == "" {
+		return compiledValidationRule{}, fmt.Errorf("%w: name must not be empty", ErrInvalidValidationRule)
+	}
+	if strings.TrimSpace(r.Condition) == "" {
+		return compiledValidationRule{}, fmt.Errorf("%w: condition of rule %s must not be empty", ErrInvalidValidationRule, r.Name)
+	}
+
+	condition, err := parseValidationRuleTemplate(r.Name, r.Condition)
+	if err != nil {
+		return compiledValidationRule{}, fmt.Errorf("%w: condition of rule %s: %w", ErrInvalidValidationRule, r.Name, err)
+	}
+	message, err := parseValidationRuleTemplate(r.Name, r.ErrorMessage)
+	if err != nil {
+		return compiledValidationRule{}, fmt.Errorf("%w: error message of rule %s: %w", ErrInvalidValidationRule, r.Name, err)
+	}
+
+	return compiledValidationRule{
+		name:      r.Name,
+		condition: condition,
+		message:   message,
+		dependsOn: append(getTemplateFields(condition), getTemplateFields(message)...),
+	}, nil
+}
+
+// parseValidationRuleTemplate parses a template of a validation rule. A value that is not set renders as an empty
+// string rather than "<no value>".
+func parseValidationRuleTemplate(name string, text string) (*template.Template, error) {
+	return template.New(name).Funcs(validationRuleFuncs).Option("missingkey=zero").Parse(text)
+}
+
+// validateRules runs the validation rules of the context against the values. Rules that use values in the unknown list
+// are skipped, since those values are not known yet.
+func (c *ProviderConfig) validateRules(values map[string]string, unknown []string) []error {
+	errs := []error{}
+	for _, rule := range c.validations {
+		r := c.engine.getValidationRule(rule)
+		if r.err != nil {
+			errs = append(errs, &ValidationRuleError{Rule: rule.Name, Err: r.err})
+			continue
+		}
+		if slices.ContainsFunc(r.dependsOn, func(name string) bool { return slices.Contains(unknown, name) }) {
+			continue
+		}
+		if err := r.validate(values); err != nil {
+			errs = append(errs, &ValidationRuleError{Rule: r.name, Err: err})
+		}
+	}
+	return errs
+}
+
+// validate evaluates the condition of the rule and returns its error message if the condition is false.
+func (r *compiledValidationRule) validate(values map[string]string) error {
+	var result bytes.Buffer
+	if err := r.condition.Execute(&result, values); err != nil {
+		return fmt.Errorf("%w: condition of rule %s: %w", ErrInvalidValidationRule, r.name, err)
+	}
+
+	switch strings.TrimSpace(result.String()) {
+	case "true":
+		return nil
+	case "false":
+	default:
+		return fmt.Errorf("%w: condition of rule %s rendered %q, expected true or false", ErrInvalidValidationRule, r.name, strings.TrimSpace(result.String()))
+	}
+
+	var message bytes.Buffer
+	if err := r.message.Execute(&message, values); err != nil || message.Len() == 0 {
+		return fmt.Errorf("%w: %s", ErrValidationRuleFailed, r.name)
+	}
+	return fmt.Errorf("%w: %s: %s", ErrValidationRuleFailed, r.name, message.String())
+}
+
+// GetValidationRules returns the validation rules of the context.
+func (c *ProviderConfig) GetValidationRules() []ValidationRule {
+	return c.validations
+}
+
+// WithValidationRules is a functional option for adding validation rules to the context when creating a new provider
+// config. Rules are merged by name, so a later option replaces a rule with the same name and keeps its position.
+func WithValidationRules(rules []ValidationRule) func(*ProviderConfig) {
+	return func(obj *ProviderConfig) {
+		for _, r := range rules {
+			i := slices.IndexFunc(obj.validations, func(existing ValidationRule) bool { return existing.Name == r.Name })
+			if i >= 0 {
+				obj.validations[i] = r
+				continue
+			}
+			obj.validations = append(obj.validations, r)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getValidationRuleProviderConfig(t *testing.T, rules ...ValidationRule) *ProviderConfig {
+	t.Helper()
+	properties := []Property{
+		*NewProperty("namespace"),
+		*NewProperty("stage"),
+		*NewProperty("environment"),
+		*NewProperty("name"),
+	}
+	c, err := NewProviderConfig(properties, []string{}, map[string]string{}, WithValidationRules(rules))
+	assert.NoError(t, err)
+	return c
+}
+
+func TestValidatePropertiesValidationRules(t *testing.T) {
+	c := getValidationRuleProviderConfig(t,
+		ValidationRule{
+			Name:         "name_length",
+			Condition:    "{{ le (len (print .namespace .name)) 10 }}",
+			ErrorMessage: "{{.namespace}} and {{.name}} are longer than 10 characters",
+		},
+		ValidationRule{
+			Name:         "prod_regions",
+			Condition:    `{{ if eq .stage "prod" }}{{ oneOf .environment "ue1" "uw2" }}{{ else }}true{{ end }}`,
+			ErrorMessage: "{{.environment}} is not a region of {{.stage}}",
+		},
+	)
+
+	testCases := map[string]struct {
+		values   map[string]string
+		expected []string
+	}{
+		"valid": {
+			values: map[string]string{"namespace": "cp", "stage": "prod", "environment": "ue1", "name": "app"},
+		},
+		"rule not applicable": {
+			values: map[string]string{"namespace": "cp", "stage": "dev", "environment": "ew1", "name": "app"},
+		},
+		"too long": {
+			values:   map[string]string{"namespace": "cp", "name": "application"},
+			expected: []string{"validation rule failed: name_length: cp and application are longer than 10 characters"},
+		},
+		"both fail": {
+			values: map[string]string{"namespace": "cp", "stage": "prod", "environment": "ew1", "name": "application"},
+			expected: []string{
+				"validation rule failed: name_length: cp and application are longer than 10 characters",
+				"validation rule failed: prod_regions: ew1 is not a region of prod",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			errs := c.ValidateProperties(tc.values)
+			messages := []string{}
+			for _, err := range errs {
+				assert.ErrorIs(t, err, ErrValidationRuleFailed)
+				messages = append(messages, err.Error())
+			}
+			assert.ElementsMatch(t, tc.expected, messages)
+		})
+	}
+}
+
+func TestValidatePropertiesValidationRuleError(t *testing.T) {
+	c := getValidationRuleProviderConfig(t, ValidationRule{Name: "stage", Condition: `{{ matches "^(dev|prod)$" .stage }}`})
+
+	errs := c.ValidateProperties(map[string]string{"stage": "qa"})
+	assert.Len(t, errs, 1)
+	var ruleErr *ValidationRuleError
+	assert.ErrorAs(t, errs[0], &ruleErr)
+	assert.Equal(t, "stage", ruleErr.Rule)
+	// A rule without an error message reports its name
+	assert.Equal(t, "validation rule failed: stage", errs[0].Error())
+}
+
+func TestValidatePropertiesValidationRuleNotBoolean(t *testing.T) {
+	c := getValidationRuleProviderConfig(t, ValidationRule{Name: "stage", Condition: "{{.stage}}"})
+
+	errs := c.ValidateProperties(map[string]string{"stage": "qa"})
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrInvalidValidationRule)
+	assert.Equal(t, `invalid validation rule: condition of rule stage rendered "qa", expected true or false`, errs[0].Error())
+}
+
+func TestValidateKnownPropertiesValidationRules(t *testing.T) {
+	c := getValidationRuleProviderConfig(t, ValidationRule{Name: "name", Condition: `{{ hasPrefix .name "app" }}`})
+
+	// The name is only known during apply, so the rule runs then
+	assert.Empty(t, c.ValidateKnownProperties(map[string]string{}, []string{"name"}))
+	assert.Len(t, c.ValidateKnownProperties(map[string]string{"name": "web"}, nil), 1)
+}
+
+func TestNewProviderConfigInvalidValidationRule(t *testing.T) {
+	testCases := map[string]ValidationRule{
+		"no name":           {Condition: "true"},
+		"no condition":      {Name: "rule"},
+		"invalid condition": {Name: "rule", Condition: "{{ .stage"},
+		"invalid message":   {Name: "rule", Condition: "true", ErrorMessage: "{{ unknown }}"},
+	}
+
+	for name, rule := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewProviderConfig([]Property{}, []string{}, map[string]string{}, WithValidationRules([]ValidationRule{rule}))
+			assert.ErrorIs(t, err, ErrInvalidValidationRule)
+		})
+	}
+}
+
+func TestWithValidationRulesMergesByName(t *testing.T) {
+	c, err := NewProviderConfig([]Property{}, []string{}, map[string]string{},
+		WithValidationRules([]ValidationRule{{Name: "a", Condition: "true"}, {Name: "b", Condition: "true"}}),
+		WithValidationRules([]ValidationRule{{Name: "a", Condition: "false"}, {Name: "c", Condition: "true"}}),
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []ValidationRule{{Name: "a", Condition: "false"}, {Name: "b", Condition: "true"}, {Name: "c", Condition: "true"}}, c.GetValidationRules())
+}
+
+func TestValidationRulesApplyToChildren(t *testing.T) {
+	c := getValidationRuleProviderConfig(t, ValidationRule{Name: "name", Condition: `{{ ne .name "" }}`, ErrorMessage: "name is required"})
+
+	child, err := c.NewChild(nil, map[string]string{"stage": "dev"})
+	assert.NoError(t, err)
+	_, errs := child.GetTags(nil, nil, nil)
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrValidationRuleFailed)
+}
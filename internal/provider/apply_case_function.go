@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudposse/terraform-provider-context/pkg/cases"
+	"github.com/cloudposse/terraform-provider-context/pkg/slice"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ApplyCaseFunction{}
+
+func NewApplyCaseFunction() function.Function {
+	return &ApplyCaseFunction{}
+}
+
+// ApplyCaseFunction defines the function implementation.
+type ApplyCaseFunction struct{}
+
+func (f *ApplyCaseFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "apply_case"
+}
+
+func (f *ApplyCaseFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Converts a string to the given case",
+		MarkdownDescription: "Converts a string to the given case, exactly like `context_tags` does for `tags_key_case` and `tags_value_case`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "The string to convert.",
+			},
+			function.StringParameter{
+				Name:                "case",
+				MarkdownDescription: "The case to convert to. Valid values are: none, camel, lower, snake, title, upper.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ApplyCaseFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	var caseName string
+
+	resp.Error = req.Arguments.Get(ctx, &value, &caseName)
+	if resp.Error != nil {
+		return
+	}
+
+	if !slice.Contains(ValidCases, caseName) {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("%s: %s, valid values are: %s", cases.ErrUnknownCase, caseName, strings.Join(ValidCases, ", ")))
+		return
+	}
+
+	c, err := cases.FromString(caseName)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, c.Apply(value))
+}
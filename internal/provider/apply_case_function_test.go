@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyCaseFunction(t *testing.T) {
+	testCases := []struct {
+		value    string
+		caseName string
+		expected string
+	}{
+		{"Hello World", "none", "Hello World"},
+		{"hello_world", "camel", "helloWorld"},
+		{"Hello World", "lower", "hello world"},
+		{"HelloWorld", "snake", "hello_world"},
+		{"hello_world", "title", "HelloWorld"},
+		{"Hello World", "upper", "HELLO WORLD"},
+	}
+
+	for _, tc := range testCases {
+		result, funcErr := callTestFunction(NewApplyCaseFunction(), types.StringUnknown(), types.StringValue(tc.value), types.StringValue(tc.caseName))
+		assert.Nil(t, funcErr)
+		assert.Equal(t, types.StringValue(tc.expected), result)
+	}
+}
+
+func TestApplyCaseFunctionInvalidCase(t *testing.T) {
+	_, funcErr := callTestFunction(NewApplyCaseFunction(), types.StringUnknown(), types.StringValue("foo"), types.StringValue("kebab"))
+	assert.NotNil(t, funcErr)
+	assert.Equal(t, int64(1), *funcErr.FunctionArgument)
+	assert.Contains(t, funcErr.Text, "unknown case: kebab")
+}
@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudposse/terraform-provider-context/internal/framework"
+	"github.com/cloudposse/terraform-provider-context/internal/model"
+	"github.com/cloudposse/terraform-provider-context/pkg/cases"
+	"github.com/cloudposse/terraform-provider-context/pkg/stringHelpers"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                   = &ChildDataSource{}
+	_ datasource.DataSourceWithConfigure      = &ChildDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &ChildDataSource{}
+)
+
+func NewChildDataSource() datasource.DataSource {
+	return &ChildDataSource{}
+}
+
+// ChildDataSource defines the data source implementation.
+type ChildDataSource struct {
+	providerData *model.ProviderData
+}
+
+// ChildDataSourceModel describes the data source data model.
+type ChildDataSourceModel struct {
+	Context       types.String `tfsdk:"context"`
+	Delimiter     types.String `tfsdk:"delimiter"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	Encoded       types.String `tfsdk:"encoded"`
+	Id            types.String `tfsdk:"id"`
+	PropertyOrder types.List   `tfsdk:"property_order"`
+	TagsKeyCase   types.String `tfsdk:"tags_key_case"`
+	TagsValueCase types.String `tfsdk:"tags_value_case"`
+	Values        types.Map    `tfsdk:"values"`
+}
+
+// isFullyKnown returns false when any of the settings of the child is unknown, which happens at plan time when they are
+// computed from resources that are not created yet.
+func (m *ChildDataSourceModel) isFullyKnown() bool {
+	for _, v := range []attr.Value{m.Context, m.Delimiter, m.Enabled, m.PropertyOrder, m.TagsKeyCase, m.TagsValueCase, m.Values} {
+		if !framework.IsFullyKnown(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *ChildDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_child"
+}
+
+func (d *ChildDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Child data source. Derives a new context from the provider context, or from the `context` of another child, and returns it serialized so it can be passed to the `context` attribute of labels, tags and other children.",
+
+		Attributes: map[string]schema.Attribute{
+			"context": getContextDSSchema("The serialized context to derive the child from. Defaults to the provider context."),
+			"delimiter": schema.StringAttribute{
+				MarkdownDescription: "The delimiter to use in labels created from the child context. Defaults to the delimiter of the parent context.",
+				Optional:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Set to false to disable the child context. Defaults to the enabled flag of the parent context.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"encoded": schema.StringAttribute{
+				MarkdownDescription: "The serialized child context.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Child identifier",
+				Computed:            true,
+			},
+			"property_order": schema.ListAttribute{
+				MarkdownDescription: "The order of the properties in labels created from the child context. Defaults to the property order of the parent context.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"tags_key_case": schema.StringAttribute{
+				MarkdownDescription: "The case to use for the keys of tags created from the child context. Defaults to the case of the parent context. Valid values are: none, camel, lower, snake, title, upper.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(ValidCases...),
+				},
+			},
+			"tags_value_case": schema.StringAttribute{
+				MarkdownDescription: "The case to use for the values of tags created from the child context. Defaults to the case of the parent context. Valid values are: none, camel, lower, snake, title, upper.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(ValidCases...),
+				},
+			},
+			"values": schema.MapAttribute{
+				MarkdownDescription: "Map of values to override or add to the values of the parent context.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *ChildDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*model.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// getChildOptions returns the options that override the settings of the parent context.
+func (d *ChildDataSource) getChildOptions(config *ChildDataSourceModel, resp *datasource.ReadResponse) []func(*model.ProviderConfig) {
+	options := []func(*model.ProviderConfig){}
+
+	if !config.Delimiter.IsNull() {
+		options = append(options, model.WithDelimiter(config.Delimiter.ValueString()))
+	}
+	if !config.Enabled.IsNull() {
+		options = append(options, model.WithEnabled(config.Enabled.ValueBool()))
+	}
+	if !config.TagsKeyCase.IsNull() {
+		tagsKeyCase, err := cases.FromString(config.TagsKeyCase.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("tags_key_case"), "Failed to convert tags_key_case to model", err.Error())
+			return nil
+		}
+		options = append(options, model.WithTagsKeyCase(tagsKeyCase))
+	}
+	if !config.TagsValueCase.IsNull() {
+		tagsValueCase, err := cases.FromString(config.TagsValueCase.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("tags_value_case"), "Failed to convert tags_value_case to model", err.Error())
+			return nil
+		}
+		options = append(options, model.WithTagsValueCase(tagsValueCase))
+	}
+
+	return options
+}
+
+// ValidateConfig validates the known values of a child whose settings are not all known yet. Terraform defers reading
+// such a data source until apply, so this is the only chance to report invalid values at plan time.
+//
+//nolint:gocritic
+func (d *ChildDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config ChildDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.isFullyKnown() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateKnownValues(d.providerData, config.Context, config.Enabled, config.Values)...)
+}
+
+//nolint:gocritic
+func (d *ChildDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ChildDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parent, diags := getContextProviderConfig(d.providerData, config.Context)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	values, diags := framework.FromFrameworkMap[string](ctx, config.Values)
+	resp.Diagnostics.Append(diags...)
+	propertyOrder, diags := framework.FromFrameworkList[string](ctx, config.PropertyOrder)
+	resp.Diagnostics.Append(diags...)
+	options := d.getChildOptions(&config, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	child, err := parent.NewChild(propertyOrder, values, options...)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create child context", err.Error())
+		return
+	}
+
+	// Validate the values the same way the provider does, unless the child is disabled
+	config.Enabled = types.BoolValue(child.IsEnabled())
+	if child.IsEnabled() {
+		resolved, errs := child.ResolveValues(nil)
+		addValidationErrors(append(errs, child.ValidateProperties(resolved)...), &resp.Diagnostics, values, nil)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	encoded, err := child.Encode()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to encode child context", err.Error())
+		return
+	}
+	config.Encoded = types.StringValue(encoded)
+	config.Id = types.StringValue(stringHelpers.HashString(encoded))
+
+	tflog.Trace(ctx, "read child data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// getContextProviderConfig returns the context decoded from the given serialized context or, if it is null, the
+// provider context.
+func getContextProviderConfig(providerData *model.ProviderData, encoded types.String) (*model.ProviderConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if encoded.IsNull() {
+		return providerData.ProviderConfig, diags
+	}
+
+	pc, err := model.DecodeProviderConfig(encoded.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("context"), "Invalid Context", err.Error())
+		return nil, diags
+	}
+	return pc, diags
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccChildDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccChildCfg,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.context_child.component", "encoded"),
+					resource.TestCheckResourceAttr("data.context_child.component", "enabled", "true"),
+					resource.TestCheckResourceAttr("data.context_label.component", "rendered", "cp_core_prod_api"),
+					resource.TestCheckResourceAttr("data.context_label.worker", "rendered", "cp_core_prod_api_worker"),
+					resource.TestCheckResourceAttr("data.context_label.provider", "rendered", "cp-core-prod"),
+					resource.TestCheckResourceAttr("data.context_tags.component", "tags.name", "api"),
+					resource.TestCheckResourceAttr("data.context_tags.component", "tags.namespace", "cp"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccChildDataSource_invalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "context" {
+  properties = {
+    stage = { validation_regex = "^(dev|prod)$" }
+  }
+
+  values = {
+    stage = "prod"
+  }
+}
+
+data "context_child" "test" {
+  values = {
+    stage = "qa"
+  }
+}`,
+				ExpectError: regexp.MustCompile(`(?s)Error: Value Does Not Match Regex.*stage = "qa"`),
+			},
+			{
+				Config: `
+provider "context" {}
+
+data "context_label" "test" {
+  context = "not a context"
+}`,
+				ExpectError: regexp.MustCompile(`Invalid Context`),
+			},
+		},
+	})
+}
+
+const testAccChildCfg = `
+provider "context" {
+  properties = {
+    namespace = { order = 1 }
+    tenant    = { order = 2 }
+    stage     = { order = 3 }
+    name      = { order = 4 }
+    attribute = { order = 5 }
+  }
+
+  values = {
+    namespace = "cp"
+    tenant    = "core"
+    stage     = "prod"
+  }
+}
+
+data "context_child" "component" {
+  delimiter     = "_"
+  tags_key_case = "lower"
+
+  values = {
+    name = "api"
+  }
+}
+
+data "context_child" "worker" {
+  context = data.context_child.component.encoded
+
+  values = {
+    attribute = "worker"
+  }
+}
+
+data "context_label" "component" {
+  context = data.context_child.component.encoded
+}
+
+data "context_label" "worker" {
+  context = data.context_child.worker.encoded
+}
+
+data "context_label" "provider" {}
+
+data "context_tags" "component" {
+  context = data.context_child.component.encoded
+}
+`
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/cloudposse/terraform-provider-context/internal/model"
-	mapHelpers "github.com/cloudposse/terraform-provider-context/pkg/map"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -31,12 +30,18 @@ type ConfigDataSource struct {
 type ConfigDataSourceModel struct {
 	Delimiter         types.String `tfsdk:"delimiter"`
 	Enabled           types.Bool   `tfsdk:"enabled"`
+	Encoded           types.String `tfsdk:"encoded"`
+	Explain           types.Bool   `tfsdk:"explain"`
+	Explanation       types.Object `tfsdk:"explanation"`
+	LabelFormats      types.Map    `tfsdk:"label_formats"`
+	NullLabelContext  types.Object `tfsdk:"null_label_context"`
 	Properties        types.Map    `tfsdk:"properties"`
 	PropertyOrder     types.List   `tfsdk:"property_order"`
 	ReplaceCharsRegex types.String `tfsdk:"replace_chars_regex"`
 	TagsKeyCase       types.String `tfsdk:"tags_key_case"`
 	TagsValueCase     types.String `tfsdk:"tags_value_case"`
 	Values            types.Map    `tfsdk:"values"`
+	ValueSources      types.Map    `tfsdk:"value_sources"`
 	Id                types.String `tfsdk:"id"`
 }
 
@@ -57,6 +62,25 @@ func (d *ConfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				MarkdownDescription: "Flag to indicate if the config is enabled.",
 				Computed:            true,
 			},
+			"encoded": schema.StringAttribute{
+				MarkdownDescription: "The context serialized as a versioned token. Pass it to the `context_token` of a provider in another root module, e.g. through a remote state output, or to the `context` attribute of labels, tags and children.",
+				Computed:            true,
+			},
+			"explain":     getExplainDSSchema(),
+			"explanation": getExplanationDSSchema(),
+			"label_formats": schema.MapAttribute{
+				MarkdownDescription: "A map of the label formats of the provider, rendered with the values of the context. Empty strings when the context is disabled.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"null_label_context": schema.ObjectAttribute{
+				MarkdownDescription: "The context as a terraform-null-label `context` object, to pass to modules that use `context.tf`. " +
+					"Properties that are not null-label labels and label formats that cannot be written as a null-label descriptor format are left out. " +
+					"`label_value_case` is always `none`, since labels keep the case of their values, so `tags_value_case` has no equivalent. " +
+					"`regex_replace_chars` matches nothing when `replace_chars_regex` is empty.",
+				Computed:       true,
+				AttributeTypes: model.NullLabelContextAttrTypes(),
+			},
 			"properties": schema.MapNestedAttribute{
 				MarkdownDescription: "A map of properties to use for labels created by the provider.",
 				Computed:            true,
@@ -80,12 +104,17 @@ func (d *ConfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				Computed:            true,
 			},
 			"values": schema.MapAttribute{
-				MarkdownDescription: "A map of values to use for labels created by the provider.",
+				MarkdownDescription: "A map of values to use for labels created by the provider, including defaults and derived values.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"value_sources": schema.MapAttribute{
+				MarkdownDescription: "A map of the source that supplied each value: `context_token`, `config_file`, `null_label_context`, `environment`, `provider`, `default` or `derived`.",
 				Computed:            true,
 				ElementType:         types.StringType,
 			},
 			"id": schema.StringAttribute{
-				MarkdownDescription: "Config identifier",
+				MarkdownDescription: "Config identifier, a hash of the settings, properties and values of the context.",
 				Computed:            true,
 				Optional:            true,
 			},
@@ -120,6 +149,18 @@ func (d *ConfigDataSource) setBasicConfig(config *ConfigDataSourceModel) {
 	// enabled
 	enabled := d.providerData.ProviderConfig.IsEnabled()
 	config.Enabled = types.BoolValue(enabled)
+
+	// replaceCharsRegex
+	replaceRegexChars := d.providerData.ProviderConfig.GetReplaceCharsRegex()
+	config.ReplaceCharsRegex = types.StringValue(replaceRegexChars)
+
+	// tagsKeyCase
+	tagsKeyCase := d.providerData.ProviderConfig.GetTagsKeyCase()
+	config.TagsKeyCase = types.StringValue(tagsKeyCase)
+
+	// tagsValueCase
+	tagsValueCase := d.providerData.ProviderConfig.GetTagsValueCase()
+	config.TagsValueCase = types.StringValue(tagsValueCase)
 }
 
 func (d *ConfigDataSource) setProperties(ctx context.Context, config *ConfigDataSourceModel, resp *datasource.ReadResponse) {
@@ -151,13 +192,69 @@ func (d *ConfigDataSource) setPropertyOrder(ctx context.Context, config *ConfigD
 }
 
 func (d *ConfigDataSource) setValues(ctx context.Context, config *ConfigDataSourceModel, resp *datasource.ReadResponse) {
-	values := d.providerData.ProviderConfig.GetValues()
+	values := d.providerData.ProviderConfig.GetMergedValues(nil)
 	vals, diag := types.MapValueFrom(ctx, types.StringType, values)
 	resp.Diagnostics.Append(diag...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	config.Values = vals
+
+	sources, diag := types.MapValueFrom(ctx, types.StringType, d.providerData.ProviderConfig.GetMergedValueSources(nil))
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.ValueSources = sources
+}
+
+// setLabelFormats renders every label format of the provider. A disabled context renders empty labels.
+func (d *ConfigDataSource) setLabelFormats(ctx context.Context, config *ConfigDataSourceModel, resp *datasource.ReadResponse) {
+	pc := d.providerData.ProviderConfig
+	labels := make(map[string]string, len(pc.GetLabelFormats()))
+	for _, name := range pc.GetLabelFormatNames() {
+		if !pc.IsEnabled() {
+			labels[name] = ""
+			continue
+		}
+		label, errs := pc.GetFormattedLabel(name, nil)
+		for _, err := range errs {
+			// Warnings of the values of the context are already reported when the provider is configured
+			if model.IsWarning(err) {
+				continue
+			}
+			resp.Diagnostics.AddError("Label Format Error", fmt.Sprintf("label format %q: %s", name, err))
+		}
+		labels[name] = label
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	formats, diag := types.MapValueFrom(ctx, types.StringType, labels)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.LabelFormats = formats
+}
+
+func (d *ConfigDataSource) setNullLabelContext(ctx context.Context, config *ConfigDataSourceModel, resp *datasource.ReadResponse) {
+	nullLabelContext, diag := types.ObjectValueFrom(ctx, model.NullLabelContextAttrTypes(), d.providerData.ProviderConfig.ToNullLabelContext())
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.NullLabelContext = nullLabelContext
+}
+
+func (d *ConfigDataSource) setEncoded(config *ConfigDataSourceModel, resp *datasource.ReadResponse) {
+	encoded, err := d.providerData.ProviderConfig.Encode()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to encode context", err.Error())
+		return
+	}
+	config.Encoded = types.StringValue(encoded)
 }
 
 //nolint:gocritic
@@ -179,27 +276,41 @@ func (d *ConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	// replaceCharsRegex
-	replaceRegexChars := d.providerData.ProviderConfig.GetReplaceCharsRegex()
-	config.ReplaceCharsRegex = types.StringValue(replaceRegexChars)
+	d.setValues(ctx, &config, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// tagsKeyCase
-	tagsKeyCase := d.providerData.ProviderConfig.GetTagsKeyCase()
-	config.TagsKeyCase = types.StringValue(tagsKeyCase)
+	d.setLabelFormats(ctx, &config, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// tagsValueCase
-	tagsValueCase := d.providerData.ProviderConfig.GetTagsValueCase()
-	config.TagsValueCase = types.StringValue(tagsValueCase)
+	d.setNullLabelContext(ctx, &config, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	d.setValues(ctx, &config, resp)
+	d.setEncoded(&config, resp)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// id
-	id := mapHelpers.HashMap(config)
+	id, err := d.providerData.ProviderConfig.Hash()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to hash context", err.Error())
+		return
+	}
 	config.Id = types.StringValue(id)
 
+	explanation, diags := d.providerData.ProviderConfig.ExplainValues().ToFramework(ctx, config.Explain)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.Explanation = explanation
+
 	tflog.Trace(ctx, "create config data source")
 
 	// Save data into Terraform state
@@ -21,7 +21,7 @@ func TestAccConfigDataSource(t *testing.T) {
 			{
 				Config: testAccBasicCfg,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.context_config.test", "id", "40ca2d12ec2fd2c98beb58579af00d3941b50a87f61165dac0b1bfd08dc47dbc"),
+					resource.TestMatchResourceAttr("data.context_config.test", "id", regexp.MustCompile(`^[0-9a-f]{64}$`)),
 					resource.TestCheckResourceAttr("data.context_config.test", "delimiter", "-"),
 					resource.TestCheckResourceAttr("data.context_config.test", "values.Namespace", "cp"),
 					resource.TestCheckResourceAttr("data.context_config.test", "property_order.0", "Namespace"),
@@ -100,7 +100,7 @@ provider "context" {
 }
 
 data "context_config" "test" {}`,
-				ExpectError: regexp.MustCompile(`(?s)Error running pre-apply plan: exit status 1\s+Error: Validation Error\s+with provider\["registry.terraform.io/hashicorp/context"\],\s+on terraform_plugin_test.tf line 12, in provider "context":\s+12: provider "context" {\s+value is less than minimum length: value t for property namespace is less\s+than 3`),
+				ExpectError: regexp.MustCompile(`(?s)Error running pre-apply plan: exit status 1\s+Error: Value Too Short\s+with provider\["registry.terraform.io/hashicorp/context"\].*namespace = "t".*value is less than minimum length: value t for property namespace is less\s+than 3`),
 			},
 			{
 				Config: `
@@ -121,7 +121,159 @@ provider "context" {
 }
 
 data "context_config" "test" {}`,
-				ExpectError: regexp.MustCompile(`(?s)Error running pre-apply plan: exit status 1\s+Error: Validation Error\s+with provider\["registry.terraform.io/hashicorp/context"\],\s+on terraform_plugin_test.tf line 12, in provider "context":\s+12: provider "context" {\s+value does not match regex: value TEST for property namespace does not match\s+\^\[a-z0-9-\]\+\$`),
+				ExpectError: regexp.MustCompile(`(?s)Error running pre-apply plan: exit status 1\s+Error: Value Does Not Match Regex\s+with provider\["registry.terraform.io/hashicorp/context"\].*namespace = "TEST".*value does not match regex: value TEST for property namespace does not match\s+\^\[a-z0-9-\]\+\$`),
+			},
+		},
+	})
+}
+
+func TestAccConfigDataSource_allowedValues(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "context" {
+  properties = {
+    stage = {
+      allowed_values = ["dev", "staging", "prod"]
+      allowed_value_descriptions = {
+        prod = "Production"
+      }
+    }
+  }
+
+  values = {
+    stage = "prod"
+  }
+}
+
+data "context_config" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_config.test", "properties.stage.allowed_values.#", "3"),
+					resource.TestCheckResourceAttr("data.context_config.test", "properties.stage.allowed_values.0", "dev"),
+					resource.TestCheckResourceAttr("data.context_config.test", "properties.stage.allowed_values.2", "prod"),
+					resource.TestCheckResourceAttr("data.context_config.test", "properties.stage.allowed_value_descriptions.prod", "Production"),
+				),
+			},
+			{
+				Config: `
+provider "context" {
+  properties = {
+    stage = {
+      allowed_values = ["dev", "staging", "prod"]
+    }
+  }
+
+  values = {
+    stage = "qa"
+  }
+}
+
+data "context_config" "test" {}`,
+				ExpectError: regexp.MustCompile(`(?s)Error: Value Not Allowed.*value qa for property stage is not one of: dev,\s+staging, prod`),
+			},
+		},
+	})
+}
+
+func TestAccConfigDataSource_requiredWhen(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "context" {
+  properties = {
+    namespace = {}
+    tenant = {
+      required_when = {
+        namespace = ["acme", "globex"]
+      }
+    }
+  }
+
+  values = {
+    namespace = "cp"
+  }
+}
+
+data "context_config" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_config.test", "properties.tenant.required_when.namespace.#", "2"),
+					resource.TestCheckResourceAttr("data.context_config.test", "properties.tenant.required_when.namespace.0", "acme"),
+				),
+			},
+			{
+				Config: `
+provider "context" {
+  properties = {
+    namespace = {}
+    tenant = {
+      required_when = {
+        namespace = ["acme", "globex"]
+      }
+    }
+  }
+
+  values = {
+    namespace = "acme"
+  }
+}
+
+data "context_config" "test" {}`,
+				ExpectError: regexp.MustCompile(`(?s)Error: Missing Required Value.*value for property tenant when namespace is\s+acme\s+\(one\s+of:\s+acme,\s+globex\)`),
+			},
+		},
+	})
+}
+
+func TestAccConfigDataSource_derivedValues(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "context" {
+  properties = {
+    namespace = {}
+    stage = {
+      default = "dev"
+    }
+    id = {
+      derive_from = "{{.namespace}}-{{.stage}}"
+    }
+  }
+
+  values = {
+    namespace = "cp"
+  }
+}
+
+data "context_config" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_config.test", "values.stage", "dev"),
+					resource.TestCheckResourceAttr("data.context_config.test", "values.id", "cp-dev"),
+					resource.TestCheckResourceAttr("data.context_config.test", "value_sources.stage", "default"),
+					resource.TestCheckResourceAttr("data.context_config.test", "value_sources.id", "derived"),
+					resource.TestCheckResourceAttr("data.context_config.test", "properties.id.derive_from", "{{.namespace}}-{{.stage}}"),
+				),
+			},
+			{
+				Config: `
+provider "context" {
+  properties = {
+    a = {
+      derive_from = "{{.b}}"
+    }
+    b = {
+      derive_from = "{{.a}}"
+    }
+  }
+}
+
+data "context_config" "test" {}`,
+				ExpectError: regexp.MustCompile(`derived values depend on each other: a -> b -> a`),
 			},
 		},
 	})
@@ -154,9 +306,98 @@ provider "context" {
   }
 }
 
+data "context_config" "test" {}
+
+data "context_config" "explained" {
+  explain = true
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.context_config.test", "id", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+					// The id only depends on the context, not on the other attributes of the data source
+					resource.TestCheckResourceAttrPair("data.context_config.test", "id", "data.context_config.explained", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccConfigDataSource_labelFormats(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "context" {
+  properties = {
+    tenant = { order = 1 }
+    stage  = { order = 2 }
+    name   = { order = 3 }
+  }
+
+  label_formats = {
+    stack = {
+      template = "{{.tenant}}-{{.stage}}"
+    }
+    short = {
+      properties = ["stage", "name"]
+      delimiter  = "_"
+    }
+  }
+
+  values = {
+    tenant = "core"
+    stage  = "prod"
+    name   = "example"
+  }
+}
+
+data "context_config" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_config.test", "label_formats.%", "2"),
+					resource.TestCheckResourceAttr("data.context_config.test", "label_formats.stack", "core-prod"),
+					resource.TestCheckResourceAttr("data.context_config.test", "label_formats.short", "prod_example"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccConfigDataSource_nullLabelContext(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "context" {
+  properties = {
+    namespace = { order = 1 }
+    stage     = { order = 2 }
+    name      = { order = 3, include_in_tags = false }
+  }
+
+  label_formats = {
+    stack = {
+      template = "{{.namespace}}-{{.stage}}"
+    }
+  }
+
+  values = {
+    namespace = "cp"
+    stage     = "prod"
+    name      = "app"
+  }
+}
+
 data "context_config" "test" {}`,
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("data.context_config.test", "id", "40ca2d12ec2fd2c98beb58579af00d3941b50a87f61165dac0b1bfd08dc47dbc"),
+					resource.TestCheckResourceAttr("data.context_config.test", "null_label_context.enabled", "true"),
+					resource.TestCheckResourceAttr("data.context_config.test", "null_label_context.namespace", "cp"),
+					resource.TestCheckNoResourceAttr("data.context_config.test", "null_label_context.tenant"),
+					resource.TestCheckResourceAttr("data.context_config.test", "null_label_context.delimiter", "-"),
+					resource.TestCheckResourceAttr("data.context_config.test", "null_label_context.label_order.#", "3"),
+					resource.TestCheckResourceAttr("data.context_config.test", "null_label_context.labels_as_tags.#", "2"),
+					resource.TestCheckResourceAttr("data.context_config.test", "null_label_context.descriptor_formats.stack.format", "%v-%v"),
+					resource.TestCheckResourceAttr("data.context_config.test", "null_label_context.descriptor_formats.stack.labels.1", "stage"),
 				),
 			},
 		},
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"sort"
+
+	"github.com/cloudposse/terraform-provider-context/internal/model"
+)
+
+// configLayer holds the properties, property order, values and options read from one source of provider
+// configuration, such as a context file or the provider block itself.
+type configLayer struct {
+	properties    []model.Property
+	propertyOrder []string
+	values        map[string]string
+	valueSources  map[string]string
+	options       []func(*model.ProviderConfig)
+}
+
+// newContextFileLayer returns a layer with the settings of a context file, tagging its values with the given source.
+func newContextFileLayer(contextFile *model.ContextFile, source string) configLayer {
+	return configLayer{
+		properties:    contextFile.Properties,
+		propertyOrder: contextFile.PropertyOrder,
+		values:        contextFile.Values,
+		options:       contextFile.Options(),
+	}.withValueSource(source)
+}
+
+// newProviderConfigLayer returns a layer with the settings of a context, such as one decoded from a context token,
+// tagging its values with the given source.
+func newProviderConfigLayer(pc *model.ProviderConfig, source string) configLayer {
+	return configLayer{
+		properties:    pc.GetProperties(),
+		propertyOrder: pc.GetPropertyOrder(),
+		values:        pc.GetValues(),
+		options:       pc.Options(),
+	}.withValueSource(source)
+}
+
+// withValueSource records the given source for every value of the layer.
+func (l configLayer) withValueSource(source string) configLayer {
+	l.valueSources = make(map[string]string, len(l.values))
+	for k := range l.values {
+		l.valueSources[k] = source
+	}
+	return l
+}
+
+// propertyNames returns the set of the names of the properties of the layer.
+func (l configLayer) propertyNames() map[string]bool {
+	names := make(map[string]bool, len(l.properties))
+	for _, p := range l.properties {
+		names[p.Name] = true
+	}
+	return names
+}
+
+// overrideWith returns a new layer where the settings of the other layer take precedence over this one. Properties
+// and values are merged by name, the property order is replaced if the other layer sets one and options are applied
+// after the options of this layer.
+func (l configLayer) overrideWith(other configLayer) configLayer {
+	properties := map[string]model.Property{}
+	for _, p := range l.properties {
+		properties[p.Name] = p
+	}
+	for _, p := range other.properties {
+		properties[p.Name] = p
+	}
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	merged := configLayer{
+		properties:    make([]model.Property, 0, len(names)),
+		propertyOrder: l.propertyOrder,
+		values:        make(map[string]string, len(l.values)+len(other.values)),
+		valueSources:  make(map[string]string, len(l.values)+len(other.values)),
+		options:       append(append([]func(*model.ProviderConfig){}, l.options...), other.options...),
+	}
+	for _, name := range names {
+		merged.properties = append(merged.properties, properties[name])
+	}
+	if len(other.propertyOrder) > 0 {
+		merged.propertyOrder = other.propertyOrder
+	}
+	for k, v := range l.values {
+		merged.values[k] = v
+		merged.valueSources[k] = l.valueSources[k]
+	}
+	for k, v := range other.values {
+		merged.values[k] = v
+		merged.valueSources[k] = other.valueSources[k]
+	}
+
+	return merged
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/cloudposse/terraform-provider-context/internal/model"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigLayerOverrideWith(t *testing.T) {
+	file := configLayer{
+		properties:    []model.Property{*model.NewProperty("stage"), *model.NewProperty("namespace")},
+		propertyOrder: []string{"namespace", "stage"},
+		values:        map[string]string{"namespace": "cp", "stage": "dev"},
+	}.withValueSource(model.ValueSourceConfigFile)
+	env := configLayer{values: map[string]string{"stage": "staging", "tenant": "core"}}.withValueSource(model.ValueSourceEnvironment)
+	inline := configLayer{
+		properties: []model.Property{*model.NewProperty("namespace", model.WithRequired())},
+		values:     map[string]string{"tenant": "plat"},
+	}.withValueSource(model.ValueSourceProvider)
+
+	merged := file.overrideWith(env).overrideWith(inline)
+
+	assert.Equal(t, []model.Property{*model.NewProperty("namespace", model.WithRequired()), *model.NewProperty("stage")}, merged.properties)
+	assert.Equal(t, []string{"namespace", "stage"}, merged.propertyOrder)
+	assert.Equal(t, map[string]string{"namespace": "cp", "stage": "staging", "tenant": "plat"}, merged.values)
+	assert.Equal(t, map[string]string{
+		"namespace": model.ValueSourceConfigFile,
+		"stage":     model.ValueSourceEnvironment,
+		"tenant":    model.ValueSourceProvider,
+	}, merged.valueSources)
+}
+
+func TestGetEnvironmentLayer(t *testing.T) {
+	t.Setenv("CONTEXT_VALUE_stage", "prod")
+	t.Setenv("CUSTOM_tenant", "core")
+
+	p := &ContextProvider{}
+
+	// The environment is only read when a prefix is set
+	layer := p.getEnvironmentLayer(&providerConfigModel{})
+	assert.Empty(t, layer.values)
+	layer = p.getEnvironmentLayer(&providerConfigModel{ValuesEnvPrefix: types.StringValue("")})
+	assert.Empty(t, layer.values)
+
+	layer = p.getEnvironmentLayer(&providerConfigModel{ValuesEnvPrefix: types.StringValue("CONTEXT_VALUE_")})
+	assert.Equal(t, "prod", layer.values["stage"])
+	assert.NotContains(t, layer.values, "tenant")
+	assert.Equal(t, model.ValueSourceEnvironment, layer.valueSources["stage"])
+
+	layer = p.getEnvironmentLayer(&providerConfigModel{ValuesEnvPrefix: types.StringValue("CUSTOM_")})
+	assert.Equal(t, map[string]string{"tenant": "core"}, layer.values)
+}
+
+func TestGetNullLabelContextLayer(t *testing.T) {
+	nullLabelContext := testObject(t, map[string]attr.Value{
+		"namespace":          types.StringValue("cp"),
+		"stage":              types.StringValue("prod"),
+		"name":               types.StringNull(),
+		"attributes":         types.TupleValueMust([]attr.Type{types.StringType}, []attr.Value{types.StringValue("blue")}),
+		"id_length_limit":    types.NumberValue(big.NewFloat(20)),
+		"additional_tag_map": testObject(t, map[string]attr.Value{}),
+	})
+	config := providerConfigModel{NullLabelContext: types.DynamicValue(nullLabelContext)}
+	resp := &provider.ConfigureResponse{}
+
+	layer := (&ContextProvider{}).getNullLabelContextLayer(&config, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.Equal(t, 1, resp.Diagnostics.WarningsCount())
+	assert.Contains(t, resp.Diagnostics.Warnings()[0].Detail(), "id_length_limit is not supported")
+	assert.Equal(t, map[string]string{"namespace": "cp", "stage": "prod", "attributes": "blue"}, layer.values)
+	assert.Equal(t, model.ValueSourceNullLabelContext, layer.valueSources["namespace"])
+	assert.Len(t, layer.properties, 6)
+}
+
+func TestGetContextTokenLayer(t *testing.T) {
+	pc, err := model.NewProviderConfig([]model.Property{*model.NewProperty("stage")}, nil, map[string]string{"stage": "prod"}, model.WithDelimiter("_"))
+	assert.NoError(t, err)
+	token, err := pc.Encode()
+	assert.NoError(t, err)
+
+	config := providerConfigModel{ContextToken: types.StringValue(token)}
+	resp := &provider.ConfigureResponse{}
+
+	layer := (&ContextProvider{}).getContextTokenLayer(&config, resp)
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.Equal(t, map[string]string{"stage": "prod"}, layer.values)
+	assert.Equal(t, model.ValueSourceContextToken, layer.valueSources["stage"])
+	assert.Equal(t, []string{"stage"}, layer.propertyOrder)
+
+	config = providerConfigModel{ContextToken: types.StringValue("not a token")}
+	(&ContextProvider{}).getContextTokenLayer(&config, resp)
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Invalid Context Token", resp.Diagnostics.Errors()[0].Summary())
+}
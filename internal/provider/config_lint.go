@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/cloudposse/terraform-provider-context/internal/model"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// getLintErrorSummary returns the summary of the diagnostic for a problem with the configuration of the context.
+func getLintErrorSummary(lintErr *model.LintError) string {
+	switch {
+	case errors.Is(lintErr, model.ErrInvalidRegex) && lintErr.Setting == model.LintSettingReplaceCharsRegex:
+		return "Invalid Replace Chars Regex"
+	case errors.Is(lintErr, model.ErrInvalidRegex):
+		return "Invalid Validation Regex"
+	case errors.Is(lintErr, model.ErrInvalidLengthRange):
+		return "Invalid Length Range"
+	case errors.Is(lintErr, model.ErrUndeclaredProperty) && lintErr.Setting == model.LintSettingPropertyOrder:
+		return "Undeclared Property In Property Order"
+	case errors.Is(lintErr, model.ErrUndeclaredProperty):
+		return "Value For Undeclared Property"
+	case errors.Is(lintErr, model.ErrTagKeyCollision):
+		return "Tag Key Collision"
+	}
+	return "Invalid Configuration"
+}
+
+// getLintErrorPath returns the attribute of the provider block a problem is reported on, if the setting with the
+// problem is set in the provider block rather than in another source, such as the context file.
+func getLintErrorPath(lintErr *model.LintError, inline configLayer, replaceCharsRegexSet bool) (path.Path, bool) {
+	switch lintErr.Setting {
+	case model.LintSettingReplaceCharsRegex:
+		return path.Root("replace_chars_regex"), replaceCharsRegexSet
+	case model.LintSettingProperties:
+		return path.Root("properties").AtMapKey(lintErr.Key), inline.propertyNames()[lintErr.Key]
+	case model.LintSettingPropertyOrder:
+		i := slices.Index(inline.propertyOrder, lintErr.Key)
+		return path.Root("property_order").AtListIndex(i), i >= 0
+	case model.LintSettingValues:
+		_, ok := inline.values[lintErr.Key]
+		return path.Root("values").AtMapKey(lintErr.Key), ok
+	}
+	return path.Empty(), false
+}
+
+// addLintDiagnostics adds a diagnostic for each problem with the configuration of the context, as an error when strict
+// is set and as a warning otherwise. Problems that are also validation errors, such as an invalid validation regex of a
+// property with a value, are already reported by the validation and are skipped.
+func addLintDiagnostics(errs []error, validationErrs []error, diags *diag.Diagnostics, inline configLayer, replaceCharsRegexSet bool, strict bool) {
+	reported := map[string]bool{}
+	for _, err := range validationErrs {
+		reported[err.Error()] = true
+	}
+
+	for _, err := range errs {
+		var lintErr *model.LintError
+		if !errors.As(err, &lintErr) || reported[err.Error()] {
+			continue
+		}
+
+		summary := getLintErrorSummary(lintErr)
+		p, ok := getLintErrorPath(lintErr, inline, replaceCharsRegexSet)
+		switch {
+		case strict && ok:
+			diags.AddAttributeError(p, summary, err.Error())
+		case strict:
+			diags.AddError(summary, err.Error())
+		case ok:
+			diags.AddAttributeWarning(p, summary, err.Error())
+		default:
+			diags.AddWarning(summary, err.Error())
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/cloudposse/terraform-provider-context/internal/model"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func getLintProviderConfig(t *testing.T) *model.ProviderConfig {
+	properties := []model.Property{*model.NewProperty("namespace", model.WithValidationRegex("[")), *model.NewProperty("stage")}
+	pc, err := model.NewProviderConfig(properties, []string{"namespace", "environment"}, map[string]string{"namespace": "cp", "tenant": "core"})
+	assert.NoError(t, err)
+	return pc
+}
+
+func TestAddLintDiagnosticsWarnings(t *testing.T) {
+	pc := getLintProviderConfig(t)
+	inline := configLayer{
+		properties:    pc.GetProperties(),
+		propertyOrder: []string{"namespace", "environment"},
+	}
+
+	var diags diag.Diagnostics
+	addLintDiagnostics(pc.Lint(), nil, &diags, inline, false, false)
+
+	assert.False(t, diags.HasError())
+	assert.Len(t, diags, 3)
+
+	assert.Equal(t, "Invalid Validation Regex", diags[0].Summary())
+	assert.Equal(t, path.Root("properties").AtMapKey("namespace"), diags[0].(diag.DiagnosticWithPath).Path())
+
+	assert.Equal(t, "Undeclared Property In Property Order", diags[1].Summary())
+	assert.Equal(t, path.Root("property_order").AtListIndex(1), diags[1].(diag.DiagnosticWithPath).Path())
+
+	// The value is not set in the provider block, so there is no attribute to report it on
+	assert.Equal(t, "Value For Undeclared Property", diags[2].Summary())
+	_, ok := diags[2].(diag.DiagnosticWithPath)
+	assert.False(t, ok)
+}
+
+func TestAddLintDiagnosticsStrict(t *testing.T) {
+	pc := getLintProviderConfig(t)
+
+	var diags diag.Diagnostics
+	addLintDiagnostics(pc.Lint(), nil, &diags, configLayer{}, false, true)
+
+	assert.Len(t, diags, 3)
+	assert.Equal(t, 3, diags.ErrorsCount())
+}
+
+func TestAddLintDiagnosticsSkipsValidationErrors(t *testing.T) {
+	pc := getLintProviderConfig(t)
+	validationErrs := pc.ValidateProperties(pc.GetValues())
+
+	var diags diag.Diagnostics
+	addLintDiagnostics(pc.Lint(), validationErrs, &diags, configLayer{}, false, false)
+
+	// The invalid regex of namespace fails the validation of its value, so it is only reported once
+	assert.Len(t, diags, 2)
+	assert.Equal(t, "Undeclared Property In Property Order", diags[0].Summary())
+}
@@ -17,3 +17,6 @@ const (
 
 // ValidCases contains all valid case values.
 var ValidCases = []string{CaseNone, CaseCamel, CaseLower, CaseSnake, CaseTitle, CaseUpper}
+
+// ConfigFileEnvVar is the environment variable holding the path of the context file, used when config_file is not set.
+const ConfigFileEnvVar = "CONTEXT_CONFIG_FILE"
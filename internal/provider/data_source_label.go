@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cloudposse/terraform-provider-context/internal/framework"
 	"github.com/cloudposse/terraform-provider-context/internal/model"
 	"github.com/cloudposse/terraform-provider-context/pkg/stringHelpers"
 	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
@@ -22,6 +23,7 @@ var (
 	_ datasource.DataSource                     = &LabelDataSource{}
 	_ datasource.DataSourceWithConfigure        = &LabelDataSource{}
 	_ datasource.DataSourceWithConfigValidators = &LabelDataSource{}
+	_ datasource.DataSourceWithValidateConfig   = &LabelDataSource{}
 )
 
 func NewLabelDataSource() datasource.DataSource {
@@ -43,10 +45,22 @@ func (d *LabelDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 		MarkdownDescription: "Label data source",
 
 		Attributes: map[string]schema.Attribute{
+			"context": getContextDSSchema("A serialized context, like the `encoded` attribute of a `context_child` data source, to create the label from. Defaults to the provider context."),
 			"delimiter": schema.StringAttribute{
 				MarkdownDescription: "Delimiter to use when creating the label from properties. Conflicts with `template`.",
 				Optional:            true,
 			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Set to false to render an empty label without validating the values. Defaults to the provider's `enabled` setting.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"explain":     getExplainDSSchema(),
+			"explanation": getExplanationDSSchema(),
+			"format": schema.StringAttribute{
+				MarkdownDescription: "The name of a label format from the provider's `label_formats` to render the label with. `max_length` and `truncate` override the settings of the format. Conflicts with `delimiter`, `properties` and `template`.",
+				Optional:            true,
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Label identifier",
 				Computed:            true,
@@ -116,7 +130,33 @@ func (d *LabelDataSource) ConfigValidators(ctx context.Context) []datasource.Con
 			path.MatchRoot("properties"),
 			path.MatchRoot("template"),
 		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("format"),
+			path.MatchRoot("delimiter"),
+		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("format"),
+			path.MatchRoot("properties"),
+		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("format"),
+			path.MatchRoot("template"),
+		),
+	}
+}
+
+// ValidateConfig validates the known values of a label whose settings are not all known yet. Terraform defers reading
+// such a data source until apply, so this is the only chance to report invalid values at plan time.
+//
+//nolint:gocritic
+func (d *LabelDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config model.DataSourceLabelConfig
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.IsFullyKnown() {
+		return
 	}
+
+	resp.Diagnostics.Append(validateKnownValues(d.providerData, config.Context, config.Enabled, config.Values)...)
 }
 
 //nolint:gocritic
@@ -126,17 +166,29 @@ func (d *LabelDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 
+	pc, diags := getContextProviderConfig(d.providerData, config.Context)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Generate the label
-	label, diags := readLabel(ctx, d.providerData.ProviderConfig, &config)
+	label, explanation, diags := readLabel(ctx, pc, &config)
 	resp.Diagnostics = append(resp.Diagnostics, diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	config.Explanation, diags = explanation.ToFramework(ctx, config.Explain)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Set other properties
-	labelAsHash := stringHelpers.HashString(label)
+	labelAsHash := stringHelpers.HashString(label.ValueString())
 	config.Id = types.StringValue(labelAsHash)
-	config.Rendered = types.StringValue(label)
+	config.Rendered = label
 
 	// Write to state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
@@ -144,45 +196,111 @@ func (d *LabelDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	tflog.Trace(ctx, "create label data source")
 }
 
-// processErrors iterates through a list of errors and adds them to the diagnostics.
-func processErrors(errs []error, diags *diag.Diagnostics) {
-	for _, err := range errs {
-		if err != nil {
-			diags.AddError("Validation Error", err.Error())
-		}
+// validateKnownValues validates the values that are known when some of the values are only known during apply, so
+// invalid values are still reported at plan time. Nothing is validated while the context or the values map itself is
+// unknown, since any value of the context could still be overridden, while it is not known whether the context is
+// enabled, or while the provider is not configured, as when running terraform validate.
+func validateKnownValues(providerData *model.ProviderData, encoded types.String, enabled types.Bool, values types.Map) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if encoded.IsUnknown() || values.IsUnknown() || enabled.IsUnknown() {
+		return diags
+	}
+	if encoded.IsNull() && (providerData == nil || providerData.ProviderConfig == nil) {
+		return diags
 	}
+
+	pc, diags := getContextProviderConfig(providerData, encoded)
+	if diags.HasError() || !pc.GetMergedEnabled(enabled.ValueBoolPointer()) {
+		return diags
+	}
+
+	known, unknown := framework.FromFrameworkKnownStringMap(values)
+	addValidationErrors(pc.ValidateKnownProperties(known, unknown), &diags, known, nil)
+	return diags
 }
 
-// readLabel determines the type of label to create and calls the appropriate method to create it.
-func readLabel(ctx context.Context, pc *model.ProviderConfig, config *model.DataSourceLabelConfig) (string, diag.Diagnostics) {
+// readLabel determines the type of label to create and calls the appropriate method to create it. It also resolves the
+// enabled flag in the config. When the context is disabled, an empty label and no explanation are returned without
+// validating the values.
+func readLabel(ctx context.Context, pc *model.ProviderConfig, config *model.DataSourceLabelConfig) (types.String, *model.Explanation, diag.Diagnostics) {
+	enabled := pc.GetMergedEnabled(config.Enabled.ValueBoolPointer())
+	config.Enabled = types.BoolValue(enabled)
+	if !enabled {
+		return types.StringValue(""), nil, nil
+	}
+
+	if !config.Format.IsNull() {
+		// Render from a copy so the settings of the format are not written to the state of the data source
+		formatted := *config
+		if diags := applyLabelFormat(ctx, pc, &formatted); diags.HasError() {
+			return types.StringNull(), nil, diags
+		}
+		config = &formatted
+	}
+
+	var label string
+	var explanation *model.Explanation
+	var diags diag.Diagnostics
 	if !config.Template.IsNull() {
-		return readTemplatedLabel(ctx, pc, config)
+		label, explanation, diags = readTemplatedLabel(ctx, pc, config)
+	} else {
+		label, explanation, diags = readDelimitedLabel(ctx, pc, config)
 	}
-	return readDelimitedLabel(ctx, pc, config)
+	return types.StringValue(label), explanation, diags
 }
 
 // readTemplatedLabel creates a label using a template.
-func readTemplatedLabel(ctx context.Context, pc *model.ProviderConfig, config *model.DataSourceLabelConfig) (string, diag.Diagnostics) {
+func readTemplatedLabel(ctx context.Context, pc *model.ProviderConfig, config *model.DataSourceLabelConfig) (string, *model.Explanation, diag.Diagnostics) {
 	templatedLabel, diags := model.TemplatedLabelModel{}.FromFramework(ctx, config)
 	if diags.HasError() {
-		return "", diags
+		return "", nil, diags
 	}
 
-	label, errs := pc.GetTemplatedLabel(templatedLabel.Template, templatedLabel.Values, templatedLabel.ReplaceCharsRegex, int(templatedLabel.MaxLength), templatedLabel.Truncate)
-	processErrors(errs, &diags)
+	label, explanation, errs := pc.ExplainTemplatedLabel(templatedLabel.Template, templatedLabel.Values, templatedLabel.ReplaceCharsRegex, int(templatedLabel.MaxLength), templatedLabel.Truncate)
+	addValidationErrors(errs, &diags, templatedLabel.Values, nil)
 
-	return label, diags
+	return label, explanation, diags
 }
 
 // readDelimitedLabel creates a label using a delimiter.
-func readDelimitedLabel(ctx context.Context, pc *model.ProviderConfig, config *model.DataSourceLabelConfig) (string, diag.Diagnostics) {
+func readDelimitedLabel(ctx context.Context, pc *model.ProviderConfig, config *model.DataSourceLabelConfig) (string, *model.Explanation, diag.Diagnostics) {
 	delimitedLabel, diags := model.DelimitedLabelModel{}.FromFramework(ctx, config)
 	if diags.HasError() {
-		return "", diags
+		return "", nil, diags
+	}
+
+	label, explanation, errs := pc.ExplainDelimitedLabel(delimitedLabel.Delimiter, delimitedLabel.PropertyNames, delimitedLabel.PropertyNames, delimitedLabel.Values, delimitedLabel.ReplaceCharsRegex, int(delimitedLabel.MaxLength), delimitedLabel.Truncate)
+	addValidationErrors(errs, &diags, delimitedLabel.Values, nil)
+
+	return label, explanation, diags
+}
+
+// applyLabelFormat copies the settings of the label format named in the config into the config, so the label is
+// rendered like a label with those settings. The max_length and truncate of the config take precedence over the format.
+func applyLabelFormat(ctx context.Context, pc *model.ProviderConfig, config *model.DataSourceLabelConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	format, err := pc.GetLabelFormat(config.Format.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("format"), "Invalid Label Format", err.Error())
+		return diags
 	}
 
-	label, errs := pc.GetDelimitedLabel(delimitedLabel.Delimiter, delimitedLabel.PropertyNames, delimitedLabel.PropertyNames, delimitedLabel.Values, delimitedLabel.ReplaceCharsRegex, int(delimitedLabel.MaxLength), delimitedLabel.Truncate)
-	processErrors(errs, &diags)
+	if format.Template != nil {
+		config.Template = types.StringPointerValue(format.Template)
+	} else {
+		config.Delimiter = types.StringPointerValue(format.Delimiter)
+		config.Properties, diags = types.ListValueFrom(ctx, types.StringType, format.Properties)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	if config.MaxLength.IsNull() && format.MaxLength != nil {
+		config.MaxLength = types.Int64Value(int64(*format.MaxLength))
+	}
+	if config.Truncate.IsNull() {
+		config.Truncate = types.BoolPointerValue(format.Truncate)
+	}
 
-	return label, diags
+	return diags
 }
@@ -1,10 +1,19 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
+	"github.com/cloudposse/terraform-provider-context/internal/model"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccLabelDataSource(t *testing.T) {
@@ -107,6 +116,40 @@ func TestAccLabelDataSource(t *testing.T) {
 	})
 }
 
+func TestAccLabelDataSource_propertyOrder(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "context" {
+  properties = {
+    name        = { order = 4 }
+    namespace   = { order = 1 }
+    environment = {}
+    stage       = { order = 3 }
+    tenant      = { order = 3 }
+  }
+
+  values = {
+    namespace   = "cp"
+    tenant      = "core"
+    stage       = "prod"
+    environment = "ue1"
+    name        = "example"
+  }
+}
+
+data "context_label" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_label.test", "rendered", "ue1-cp-prod-core-example"),
+				),
+			},
+		},
+	})
+}
+
 func getConfigWithProvider(data string) string {
 	return fmt.Sprintf(`
 	provider "context" {
@@ -130,3 +173,305 @@ func getConfigWithProvider(data string) string {
 
 	%s`, data)
 }
+
+func TestAccLabelDataSource_disabled(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "context" {
+  enabled = false
+
+  properties = {
+    namespace = { required = true, order = 1 }
+    name      = { order = 2 }
+  }
+
+  values = {
+    name = "example"
+  }
+}
+
+data "context_label" "disabled" {}
+
+data "context_label" "enabled" {
+  enabled = true
+  values = {
+    namespace = "cp"
+  }
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_label.disabled", "enabled", "false"),
+					resource.TestCheckResourceAttr("data.context_label.disabled", "rendered", ""),
+					resource.TestCheckResourceAttr("data.context_label.enabled", "enabled", "true"),
+					resource.TestCheckResourceAttr("data.context_label.enabled", "rendered", "cp-example"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLabelDataSource_explain(t *testing.T) {
+	testAccExplainCfg := getConfigWithProvider(`
+	data "context_label" "test" {
+		explain             = true
+		properties          = ["Namespace", "Stage", "Name"]
+		replace_chars_regex = "[x]"
+		max_length          = 10
+		truncate            = true
+		values = {
+			"Name" = "test"
+		}
+	}
+
+	data "context_label" "plain" {
+	}
+	`)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExplainCfg,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_label.test", "explanation.truncated", "true"),
+					resource.TestCheckResourceAttr("data.context_label.test", "explanation.untruncated", "cp-prod-test"),
+					resource.TestCheckResourceAttr("data.context_label.test", "explanation.properties.#", "4"),
+					resource.TestCheckResourceAttr("data.context_label.test", "explanation.properties.0.name", "Namespace"),
+					resource.TestCheckResourceAttr("data.context_label.test", "explanation.properties.0.source", "provider"),
+					resource.TestCheckResourceAttr("data.context_label.test", "explanation.properties.0.included", "true"),
+					resource.TestCheckResourceAttr("data.context_label.test", "explanation.properties.1.name", "Stage"),
+					resource.TestCheckResourceAttr("data.context_label.test", "explanation.properties.1.included", "true"),
+					resource.TestCheckResourceAttr("data.context_label.test", "explanation.properties.2.name", "Name"),
+					resource.TestCheckResourceAttr("data.context_label.test", "explanation.properties.2.source", "data_source"),
+					resource.TestCheckResourceAttr("data.context_label.test", "explanation.properties.3.name", "Tenant"),
+					resource.TestCheckResourceAttr("data.context_label.test", "explanation.properties.3.included", "false"),
+					resource.TestCheckResourceAttr("data.context_label.test", "explanation.properties.3.reason", "not in properties"),
+					resource.TestCheckNoResourceAttr("data.context_label.plain", "explanation"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLabelDataSource_format(t *testing.T) {
+	providerCfg := `
+provider "context" {
+  properties = {
+    tenant = { order = 1 }
+    stage  = { order = 2 }
+    name   = { order = 3 }
+  }
+
+  label_formats = {
+    stack = {
+      template   = "{{.tenant}}-{{.stage}}"
+      max_length = 6
+    }
+  }
+
+  values = {
+    tenant = "core"
+    stage  = "prod"
+    name   = "example"
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerCfg + `
+data "context_label" "test" {
+  format     = "stack"
+  max_length = 0
+  values = {
+    stage = "dev"
+  }
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_label.test", "rendered", "core-dev"),
+					resource.TestCheckNoResourceAttr("data.context_label.test", "template"),
+				),
+			},
+			{
+				Config: providerCfg + `
+data "context_label" "test" {
+  format = "account"
+}`,
+				ExpectError: regexp.MustCompile(`unknown label format`),
+			},
+			{
+				Config: providerCfg + `
+data "context_label" "test" {
+  format   = "stack"
+  template = "{{.name}}"
+}`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccLabelDataSource_warningSeverity(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "context" {
+  properties = {
+    namespace = { order = 1 }
+    stage = {
+      order            = 2
+      validation_regex = "^[a-z]+$"
+      severity = {
+        validation_regex = "warning"
+      }
+    }
+  }
+
+  values = {
+    namespace = "cp"
+  }
+}
+
+data "context_label" "test" {
+  values = {
+    stage = "Dev"
+  }
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_label.test", "rendered", "cp-Dev"),
+				),
+			},
+			{
+				Config: `
+provider "context" {
+  properties = {
+    stage = {
+      severity = {
+        validation_regex = "info"
+      }
+    }
+  }
+}
+
+data "context_label" "test" {}`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+		},
+	})
+}
+
+func TestAccLabelDataSource_unknownValues(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The name is only known during apply, but the invalid namespace is reported at plan time
+			{
+				Config: getConfigWithProvider(`
+	resource "terraform_data" "name" {
+		input = "example"
+	}
+
+	data "context_label" "test" {
+		values = {
+			"Namespace" = "CP!"
+			"Name" = terraform_data.name.output
+		}
+	}
+	`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`value does not match regex`),
+			},
+			{
+				Config: getConfigWithProvider(`
+	resource "terraform_data" "name" {
+		input = "example"
+	}
+
+	data "context_label" "test" {
+		values = {
+			"Name" = terraform_data.name.output
+		}
+	}
+	`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_label.test", "rendered", "cp-core-prod-example"),
+				),
+			},
+		},
+	})
+}
+
+// validateLabelConfig calls ValidateConfig of the label data source with a config holding the given settings.
+func validateLabelConfig(t *testing.T, d *LabelDataSource, delimiter types.String, values types.Map) diag.Diagnostics {
+	ctx := context.Background()
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	assert.False(t, state.Set(ctx, &model.DataSourceLabelConfig{
+		Delimiter:   delimiter,
+		Explanation: types.ObjectNull(model.ExplanationAttrTypes()),
+		Properties:  types.ListNull(types.StringType),
+		Values:      values,
+	}).HasError())
+
+	resp := &datasource.ValidateConfigResponse{}
+	d.ValidateConfig(ctx, datasource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, resp)
+	return resp.Diagnostics
+}
+
+func TestLabelDataSourceValidateConfig(t *testing.T) {
+	d := &LabelDataSource{providerData: &model.ProviderData{ProviderConfig: getTestProviderConfig(t)}}
+
+	testCases := map[string]struct {
+		delimiter   types.String
+		values      types.Map
+		expectError bool
+	}{
+		"unknown value": {
+			values: types.MapValueMust(types.StringType, map[string]attr.Value{"Name": types.StringUnknown(), "Stage": types.StringValue("dev")}),
+		},
+		"unknown map": {
+			values: types.MapUnknown(types.StringType),
+		},
+		"invalid known value": {
+			values:      types.MapValueMust(types.StringType, map[string]attr.Value{"Name": types.StringUnknown(), "Namespace": types.StringValue("CP!")}),
+			expectError: true,
+		},
+		"unknown invalid value": {
+			values: types.MapValueMust(types.StringType, map[string]attr.Value{"Namespace": types.StringUnknown()}),
+		},
+		"invalid value with unknown setting": {
+			delimiter:   types.StringUnknown(),
+			values:      types.MapValueMust(types.StringType, map[string]attr.Value{"Namespace": types.StringValue("CP!")}),
+			expectError: true,
+		},
+		// Fully known values are validated when the data source is read
+		"invalid value when fully known": {
+			values: types.MapValueMust(types.StringType, map[string]attr.Value{"Namespace": types.StringValue("CP!")}),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diags := validateLabelConfig(t, d, tc.delimiter, tc.values)
+			assert.Equal(t, tc.expectError, diags.HasError())
+		})
+	}
+}
+
+func TestLabelDataSourceValidateConfigUnconfigured(t *testing.T) {
+	// terraform validate does not configure the provider, so there is no context to validate the values against
+	values := types.MapValueMust(types.StringType, map[string]attr.Value{"Name": types.StringUnknown(), "Namespace": types.StringValue("CP!")})
+	diags := validateLabelConfig(t, &LabelDataSource{}, types.StringNull(), values)
+	assert.False(t, diags.HasError())
+}
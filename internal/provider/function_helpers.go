@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudposse/terraform-provider-context/internal/model"
+	"github.com/cloudposse/terraform-provider-context/pkg/slice"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	ErrInvalidOverrides       = errors.New("overrides must be an object")
+	ErrUnsupportedOverride    = errors.New("unsupported override")
+	ErrInvalidOverrideValue   = errors.New("invalid override value")
+	ErrConflictingOverrides   = errors.New("conflicting overrides")
+	ErrTooManyOverrideObjects = errors.New("at most one overrides object may be given")
+	ErrNegativeMaxLength      = errors.New("\"max_length\" must be at least 0")
+)
+
+// getContextParameter returns the context parameter of the label and tags functions.
+func getContextParameter() function.StringParameter {
+	return function.StringParameter{
+		Name: "context",
+		MarkdownDescription: "The serialized context to render from, like the `encoded` attribute of a `context_config` or `context_child` " +
+			"data source.",
+	}
+}
+
+// getFunctionArguments reads the context and the overrides arguments of the label and tags functions. Terraform calls
+// provider functions on a provider instance that was never configured, so the context is passed to the function and
+// decoded here rather than read from the provider.
+func getFunctionArguments(ctx context.Context, req function.RunRequest, allowed []string) (*model.ProviderConfig, functionOverrides, *function.FuncError) {
+	var encoded string
+	var args []types.Dynamic
+	if funcErr := req.Arguments.Get(ctx, &encoded, &args); funcErr != nil {
+		return nil, functionOverrides{}, funcErr
+	}
+
+	pc, err := model.DecodeProviderConfig(encoded)
+	if err != nil {
+		return nil, functionOverrides{}, function.NewArgumentFuncError(0, err.Error())
+	}
+
+	overrides, funcErr := getFunctionOverrides(ctx, args, allowed)
+	return pc, overrides, funcErr
+}
+
+// functionOverrides holds the attributes of the optional overrides object passed to a provider function.
+type functionOverrides struct {
+	// attributes are the attributes of the overrides object, keyed by name.
+	attributes map[string]attr.Value
+	// position is the position of the overrides argument in the function call.
+	position int64
+	// present is true when the overrides object was passed to the function.
+	present bool
+}
+
+// getFunctionOverrides reads the variadic overrides argument of a provider function and checks that it only contains
+// the allowed attributes. The overrides object follows the context, so it is always the second argument.
+func getFunctionOverrides(ctx context.Context, args []types.Dynamic, allowed []string) (functionOverrides, *function.FuncError) {
+	const position = 1
+
+	overrides := functionOverrides{attributes: map[string]attr.Value{}, position: position}
+	if len(args) == 0 {
+		return overrides, nil
+	}
+	if len(args) > 1 {
+		return overrides, function.NewArgumentFuncError(position+1, ErrTooManyOverrideObjects.Error())
+	}
+
+	overrides.present = true
+	value := args[0].UnderlyingValue()
+	switch v := value.(type) {
+	case nil:
+		return overrides, nil
+	case types.Object:
+		if !v.IsNull() {
+			overrides.attributes = v.Attributes()
+		}
+	case types.Map:
+		if !v.IsNull() {
+			overrides.attributes = v.Elements()
+		}
+	default:
+		if !value.IsNull() {
+			return overrides, function.NewArgumentFuncError(position, fmt.Sprintf("%s, got %s", ErrInvalidOverrides, value.Type(ctx)))
+		}
+	}
+
+	for key := range overrides.attributes {
+		if !slice.Contains(allowed, key) {
+			sorted := append([]string{}, allowed...)
+			sort.Strings(sorted)
+			return overrides, overrides.error(fmt.Sprintf("%s: %q, valid attributes are: %s", ErrUnsupportedOverride, key, strings.Join(sorted, ", ")))
+		}
+	}
+
+	return overrides, nil
+}
+
+// error returns a function error with the given text. The error points at the overrides argument when it was passed
+// to the function.
+func (o functionOverrides) error(text string) *function.FuncError {
+	if o.present {
+		return function.NewArgumentFuncError(o.position, text)
+	}
+	return function.NewFuncError(text)
+}
+
+// diagnosticsError converts error diagnostics into a function error pointing at the overrides argument.
+func (o functionOverrides) diagnosticsError(diags diag.Diagnostics) *function.FuncError {
+	var funcErr *function.FuncError
+	for _, d := range diags.Errors() {
+		funcErr = function.ConcatFuncErrors(funcErr, o.error(fmt.Sprintf("%s: %s", d.Summary(), d.Detail())))
+	}
+	return funcErr
+}
+
+// has returns true when the given attribute is set to a non-null value.
+func (o functionOverrides) has(key string) bool {
+	v, ok := o.attributes[key]
+	return ok && !v.IsNull()
+}
+
+// conflicts returns an error if both of the given attributes are set.
+func (o functionOverrides) conflicts(first, second string) error {
+	if o.has(first) && o.has(second) {
+		return fmt.Errorf("%w: %q cannot be used together with %q", ErrConflictingOverrides, first, second)
+	}
+	return nil
+}
+
+// getString returns the given attribute as a types.String.
+func (o functionOverrides) getString(key string) (types.String, error) {
+	if !o.has(key) {
+		return types.StringNull(), nil
+	}
+	v, ok := o.attributes[key].(types.String)
+	if !ok {
+		return types.StringNull(), fmt.Errorf("%w: %q must be a string", ErrInvalidOverrideValue, key)
+	}
+	return v, nil
+}
+
+// getBool returns the given attribute as a types.Bool.
+func (o functionOverrides) getBool(key string) (types.Bool, error) {
+	if !o.has(key) {
+		return types.BoolNull(), nil
+	}
+	v, ok := o.attributes[key].(types.Bool)
+	if !ok {
+		return types.BoolNull(), fmt.Errorf("%w: %q must be a bool", ErrInvalidOverrideValue, key)
+	}
+	return v, nil
+}
+
+// getInt64 returns the given attribute as a types.Int64. Terraform passes numbers in dynamic values as types.Number,
+// which must hold a whole number.
+func (o functionOverrides) getInt64(key string) (types.Int64, error) {
+	if !o.has(key) {
+		return types.Int64Null(), nil
+	}
+	switch v := o.attributes[key].(type) {
+	case types.Int64:
+		return v, nil
+	case types.Number:
+		if v.ValueBigFloat().IsInt() {
+			i, _ := v.ValueBigFloat().Int64()
+			return types.Int64Value(i), nil
+		}
+	}
+	return types.Int64Null(), fmt.Errorf("%w: %q must be a whole number", ErrInvalidOverrideValue, key)
+}
+
+// getStringMap returns the given attribute, an object or map of strings, as a types.Map.
+func (o functionOverrides) getStringMap(key string) (types.Map, error) {
+	if !o.has(key) {
+		return types.MapNull(types.StringType), nil
+	}
+
+	var elements map[string]attr.Value
+	switch v := o.attributes[key].(type) {
+	case types.Object:
+		elements = v.Attributes()
+	case types.Map:
+		elements = v.Elements()
+	default:
+		return types.MapNull(types.StringType), fmt.Errorf("%w: %q must be a map of strings", ErrInvalidOverrideValue, key)
+	}
+
+	for k, e := range elements {
+		if _, ok := e.(types.String); !ok {
+			return types.MapNull(types.StringType), fmt.Errorf("%w: %q must be a map of strings, %q is not a string", ErrInvalidOverrideValue, key, k)
+		}
+	}
+
+	m, diags := types.MapValue(types.StringType, elements)
+	if diags.HasError() {
+		return types.MapNull(types.StringType), fmt.Errorf("%w: %q must be a map of strings", ErrInvalidOverrideValue, key)
+	}
+	return m, nil
+}
+
+// getStringList returns the given attribute, a tuple or list of strings, as a types.List.
+func (o functionOverrides) getStringList(key string) (types.List, error) {
+	if !o.has(key) {
+		return types.ListNull(types.StringType), nil
+	}
+
+	var elements []attr.Value
+	switch v := o.attributes[key].(type) {
+	case types.Tuple:
+		elements = v.Elements()
+	case types.List:
+		elements = v.Elements()
+	case types.Set:
+		elements = v.Elements()
+	default:
+		return types.ListNull(types.StringType), fmt.Errorf("%w: %q must be a list of strings", ErrInvalidOverrideValue, key)
+	}
+
+	for _, e := range elements {
+		if _, ok := e.(types.String); !ok {
+			return types.ListNull(types.StringType), fmt.Errorf("%w: %q must be a list of strings", ErrInvalidOverrideValue, key)
+		}
+	}
+
+	l, diags := types.ListValue(types.StringType, elements)
+	if diags.HasError() {
+		return types.ListNull(types.StringType), fmt.Errorf("%w: %q must be a list of strings", ErrInvalidOverrideValue, key)
+	}
+	return l, nil
+}
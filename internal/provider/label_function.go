@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/cloudposse/terraform-provider-context/internal/model"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &LabelFunction{}
+
+// labelFunctionOverrides are the attributes accepted in the overrides object of the label function.
+var labelFunctionOverrides = []string{"delimiter", "enabled", "format", "max_length", "properties", "replace_chars_regex", "template", "truncate", "values"}
+
+func NewLabelFunction() function.Function {
+	return &LabelFunction{}
+}
+
+// LabelFunction defines the function implementation.
+type LabelFunction struct{}
+
+func (f *LabelFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "label"
+}
+
+func (f *LabelFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Renders a label from a serialized context",
+		MarkdownDescription: "Renders a label from a serialized context, such as the `encoded` attribute of `context_config`, the same way the `context_label` data source does. " +
+			"An optional overrides object accepts the `delimiter`, `enabled`, `format`, `max_length`, `properties`, `replace_chars_regex`, " +
+			"`template`, `truncate` and `values` attributes of the data source.",
+		Parameters: []function.Parameter{getContextParameter()},
+		VariadicParameter: function.DynamicParameter{
+			Name:                "overrides",
+			MarkdownDescription: "Object with the values and settings to override when rendering the label. `template` conflicts with `delimiter` and `properties`, and `format` conflicts with all three.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *LabelFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	pc, overrides, funcErr := getFunctionArguments(ctx, req, labelFunctionOverrides)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	config, err := getLabelFunctionConfig(overrides)
+	if err != nil {
+		resp.Error = overrides.error(err.Error())
+		return
+	}
+
+	label, _, diags := readLabel(ctx, pc, config)
+	if diags.HasError() {
+		resp.Error = overrides.diagnosticsError(diags)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, label)
+}
+
+// getLabelFunctionConfig converts the overrides object of the label function into the label data source model so the
+// label is rendered exactly like the data source renders it.
+func getLabelFunctionConfig(overrides functionOverrides) (*model.DataSourceLabelConfig, error) {
+	if err := overrides.conflicts("delimiter", "template"); err != nil {
+		return nil, err
+	}
+	if err := overrides.conflicts("properties", "template"); err != nil {
+		return nil, err
+	}
+	for _, name := range []string{"delimiter", "properties", "template"} {
+		if err := overrides.conflicts("format", name); err != nil {
+			return nil, err
+		}
+	}
+
+	var config model.DataSourceLabelConfig
+	var err error
+
+	if config.Delimiter, err = overrides.getString("delimiter"); err != nil {
+		return nil, err
+	}
+	if config.Enabled, err = overrides.getBool("enabled"); err != nil {
+		return nil, err
+	}
+	if config.Format, err = overrides.getString("format"); err != nil {
+		return nil, err
+	}
+	if config.MaxLength, err = overrides.getInt64("max_length"); err != nil {
+		return nil, err
+	}
+	if config.MaxLength.ValueInt64() < 0 {
+		return nil, ErrNegativeMaxLength
+	}
+	if config.Properties, err = overrides.getStringList("properties"); err != nil {
+		return nil, err
+	}
+	if config.ReplaceCharsRegex, err = overrides.getString("replace_chars_regex"); err != nil {
+		return nil, err
+	}
+	if config.Template, err = overrides.getString("template"); err != nil {
+		return nil, err
+	}
+	if config.Truncate, err = overrides.getBool("truncate"); err != nil {
+		return nil, err
+	}
+	if config.Values, err = overrides.getStringMap("values"); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
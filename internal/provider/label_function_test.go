@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/cloudposse/terraform-provider-context/internal/model"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// getTestProviderConfig returns the context the label and tags functions are tested with.
+func getTestProviderConfig(t *testing.T) *model.ProviderConfig {
+	properties := []model.Property{
+		*model.NewProperty("Namespace", model.WithRequired(), model.WithMaxLength(10), model.WithValidationRegex("^[a-z0-9]+$")),
+		*model.NewProperty("Tenant"),
+		*model.NewProperty("Stage"),
+		*model.NewProperty("Name"),
+	}
+	values := map[string]string{"Namespace": "cp", "Tenant": "core", "Stage": "prod", "Name": "example"}
+
+	stack := "{{.Tenant}}-{{.Stage}}"
+	formats := map[string]model.LabelFormat{"stack": {Template: &stack}}
+
+	pc, err := model.NewProviderConfig(properties, []string{"Namespace", "Tenant", "Stage", "Name"}, values, model.WithLabelFormats(formats))
+	assert.NoError(t, err)
+	return pc
+}
+
+// getTestContext returns the serialized test context.
+func getTestContext(t *testing.T) types.String {
+	encoded, err := getTestProviderConfig(t).Encode()
+	assert.NoError(t, err)
+	return types.StringValue(encoded)
+}
+
+// runTestFunction calls the function with the test context and the given variadic arguments. The result must be the
+// unknown value of the function's return type.
+func runTestFunction(t *testing.T, f function.Function, result attr.Value, args ...attr.Value) (attr.Value, *function.FuncError) {
+	variadic := make([]attr.Value, 0, len(args))
+	variadicTypes := make([]attr.Type, 0, len(args))
+	for _, arg := range args {
+		variadic = append(variadic, types.DynamicValue(arg))
+		variadicTypes = append(variadicTypes, types.DynamicType)
+	}
+	tuple, diags := types.TupleValue(variadicTypes, variadic)
+	assert.False(t, diags.HasError())
+
+	return callTestFunction(f, result, getTestContext(t), tuple)
+}
+
+// callTestFunction calls the function with the given arguments. The result must be the unknown value of the function's
+// return type.
+func callTestFunction(f function.Function, result attr.Value, args ...attr.Value) (attr.Value, *function.FuncError) {
+	req := function.RunRequest{Arguments: function.NewArgumentsData(args)}
+	resp := &function.RunResponse{Result: function.NewResultData(result)}
+	f.Run(context.Background(), req, resp)
+
+	return resp.Result.Value(), resp.Error
+}
+
+func testObject(t *testing.T, attrs map[string]attr.Value) types.Object {
+	attrTypes := make(map[string]attr.Type, len(attrs))
+	for k, v := range attrs {
+		attrTypes[k] = v.Type(context.Background())
+	}
+	obj, diags := types.ObjectValue(attrTypes, attrs)
+	assert.False(t, diags.HasError())
+	return obj
+}
+
+func TestLabelFunction(t *testing.T) {
+	f := NewLabelFunction()
+
+	result, funcErr := runTestFunction(t, f, types.StringUnknown())
+	assert.Nil(t, funcErr)
+	assert.Equal(t, types.StringValue("cp-core-prod-example"), result)
+}
+
+func TestLabelFunctionWithOverrides(t *testing.T) {
+	f := NewLabelFunction()
+
+	overrides := testObject(t, map[string]attr.Value{
+		"delimiter":  types.StringValue("~"),
+		"properties": types.TupleValueMust([]attr.Type{types.StringType, types.StringType}, []attr.Value{types.StringValue("Namespace"), types.StringValue("Name")}),
+		"values":     testObject(t, map[string]attr.Value{"Name": types.StringValue("testing")}),
+	})
+
+	result, funcErr := runTestFunction(t, f, types.StringUnknown(), overrides)
+	assert.Nil(t, funcErr)
+	assert.Equal(t, types.StringValue("cp~testing"), result)
+}
+
+func TestLabelFunctionWithTemplateAndTruncation(t *testing.T) {
+	f := NewLabelFunction()
+
+	overrides := testObject(t, map[string]attr.Value{
+		"template":   types.StringValue("{{.Namespace}}/{{.Tenant}}/{{.Stage}}/{{.Name}}"),
+		"max_length": types.NumberValue(big.NewFloat(10)),
+	})
+
+	result, funcErr := runTestFunction(t, f, types.StringUnknown(), overrides)
+	assert.Nil(t, funcErr)
+	assert.Equal(t, types.StringValue("cp/co46903"), result)
+}
+
+func TestLabelFunctionValidationError(t *testing.T) {
+	f := NewLabelFunction()
+
+	overrides := testObject(t, map[string]attr.Value{
+		"values": testObject(t, map[string]attr.Value{"Namespace": types.StringValue("Not-Valid")}),
+	})
+
+	_, funcErr := runTestFunction(t, f, types.StringUnknown(), overrides)
+	assert.NotNil(t, funcErr)
+	assert.Equal(t, int64(1), *funcErr.FunctionArgument)
+	assert.Contains(t, funcErr.Text, "value does not match regex")
+}
+
+func TestLabelFunctionInvalidOverrides(t *testing.T) {
+	f := NewLabelFunction()
+
+	testCases := map[string]struct {
+		overrides attr.Value
+		expected  string
+	}{
+		"unsupported attribute": {
+			overrides: testObject(t, map[string]attr.Value{"bogus": types.StringValue("x")}),
+			expected:  `unsupported override: "bogus"`,
+		},
+		"conflicting attributes": {
+			overrides: testObject(t, map[string]attr.Value{"delimiter": types.StringValue("_"), "template": types.StringValue("{{.Name}}")}),
+			expected:  `"delimiter" cannot be used together with "template"`,
+		},
+		"conflicting format": {
+			overrides: testObject(t, map[string]attr.Value{"format": types.StringValue("stack"), "properties": types.ListValueMust(types.StringType, []attr.Value{})}),
+			expected:  `"format" cannot be used together with "properties"`,
+		},
+		"unknown format": {
+			overrides: testObject(t, map[string]attr.Value{"format": types.StringValue("account")}),
+			expected:  `unknown label format: "account"`,
+		},
+		"wrong type": {
+			overrides: testObject(t, map[string]attr.Value{"truncate": types.StringValue("yes")}),
+			expected:  `"truncate" must be a bool`,
+		},
+		"not an object": {
+			overrides: types.StringValue("nope"),
+			expected:  "overrides must be an object",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, funcErr := runTestFunction(t, f, types.StringUnknown(), tc.overrides)
+			assert.NotNil(t, funcErr)
+			assert.Equal(t, int64(1), *funcErr.FunctionArgument)
+			assert.Contains(t, funcErr.Text, tc.expected)
+		})
+	}
+}
+
+func TestLabelFunctionWithFormat(t *testing.T) {
+	f := NewLabelFunction()
+
+	overrides := testObject(t, map[string]attr.Value{
+		"format": types.StringValue("stack"),
+		"values": testObject(t, map[string]attr.Value{"Stage": types.StringValue("dev")}),
+	})
+
+	result, funcErr := runTestFunction(t, f, types.StringUnknown(), overrides)
+	assert.Nil(t, funcErr)
+	assert.Equal(t, types.StringValue("core-dev"), result)
+}
+
+func TestLabelFunctionDisabled(t *testing.T) {
+	f := NewLabelFunction()
+
+	overrides := testObject(t, map[string]attr.Value{
+		"enabled": types.BoolValue(false),
+		"values":  testObject(t, map[string]attr.Value{"Namespace": types.StringValue("Not-Valid")}),
+	})
+
+	result, funcErr := runTestFunction(t, f, types.StringUnknown(), overrides)
+	assert.Nil(t, funcErr)
+	assert.Equal(t, types.StringValue(""), result)
+}
+
+func TestLabelFunctionUnconfiguredProvider(t *testing.T) {
+	// Terraform calls provider functions without configuring the provider, so the function must only need its arguments
+	p, ok := NewProvider("test")().(*ContextProvider)
+	assert.True(t, ok)
+
+	functions := p.Functions(context.Background())
+	f := functions[0]()
+	resp := &function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, resp)
+	assert.Equal(t, "label", resp.Name)
+
+	result, funcErr := runTestFunction(t, f, types.StringUnknown())
+	assert.Nil(t, funcErr)
+	assert.Equal(t, types.StringValue("cp-core-prod-example"), result)
+}
+
+func TestLabelFunctionInvalidContext(t *testing.T) {
+	f := NewLabelFunction()
+
+	tuple, diags := types.TupleValue([]attr.Type{}, []attr.Value{})
+	assert.False(t, diags.HasError())
+
+	_, funcErr := callTestFunction(f, types.StringUnknown(), types.StringValue("not-a-context"), tuple)
+	assert.NotNil(t, funcErr)
+	assert.Equal(t, int64(0), *funcErr.FunctionArgument)
+	assert.Contains(t, funcErr.Text, "invalid encoded context")
+}
@@ -0,0 +1,499 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudposse/terraform-provider-context/internal/model"
+	"github.com/cloudposse/terraform-provider-context/pkg/stringHelpers"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                     = &LabelResource{}
+	_ resource.ResourceWithConfigure        = &LabelResource{}
+	_ resource.ResourceWithConfigValidators = &LabelResource{}
+	_ resource.ResourceWithImportState      = &LabelResource{}
+	_ resource.ResourceWithModifyPlan       = &LabelResource{}
+)
+
+func NewLabelResource() resource.Resource {
+	return &LabelResource{}
+}
+
+// LabelResource defines the resource implementation. Unlike the label data source, the label is only rendered when
+// the resource is created, so changes to the provider context do not change it.
+type LabelResource struct {
+	providerData *model.ProviderData
+}
+
+// LabelResourceModel describes the resource data model.
+type LabelResourceModel struct {
+	Context           types.String `tfsdk:"context"`
+	Delimiter         types.String `tfsdk:"delimiter"`
+	Enabled           types.Bool   `tfsdk:"enabled"`
+	Format            types.String `tfsdk:"format"`
+	Id                types.String `tfsdk:"id"`
+	Keepers           types.Map    `tfsdk:"keepers"`
+	MaxLength         types.Int64  `tfsdk:"max_length"`
+	PreventChange     types.Bool   `tfsdk:"prevent_change"`
+	Properties        types.List   `tfsdk:"properties"`
+	Rendered          types.String `tfsdk:"rendered"`
+	RenderedValues    types.Map    `tfsdk:"rendered_values"`
+	ReplaceCharsRegex types.String `tfsdk:"replace_chars_regex"`
+	Template          types.String `tfsdk:"template"`
+	Truncate          types.Bool   `tfsdk:"truncate"`
+	Values            types.Map    `tfsdk:"values"`
+}
+
+// toLabelConfig converts the resource model into the label data source model so the label is rendered exactly like
+// the data source renders it.
+func (m *LabelResourceModel) toLabelConfig() *model.DataSourceLabelConfig {
+	return &model.DataSourceLabelConfig{
+		Context:           m.Context,
+		Delimiter:         m.Delimiter,
+		Enabled:           m.Enabled,
+		Explain:           types.BoolNull(),
+		Format:            m.Format,
+		MaxLength:         m.MaxLength,
+		Properties:        m.Properties,
+		ReplaceCharsRegex: m.ReplaceCharsRegex,
+		Template:          m.Template,
+		Truncate:          m.Truncate,
+		Values:            m.Values,
+	}
+}
+
+// isImported returns true when the label was imported and has not been rendered from the context since, in which case
+// the values it was rendered from are not known.
+func (m *LabelResourceModel) isImported() bool {
+	return m.RenderedValues.IsNull()
+}
+
+// hasSameInputs returns true when none of the arguments that replace the resource differ from the other model.
+func (m *LabelResourceModel) hasSameInputs(other *LabelResourceModel) bool {
+	return m.Context.Equal(other.Context) &&
+		m.Delimiter.Equal(other.Delimiter) &&
+		m.Enabled.Equal(other.Enabled) &&
+		m.Format.Equal(other.Format) &&
+		m.Keepers.Equal(other.Keepers) &&
+		m.MaxLength.Equal(other.MaxLength) &&
+		m.Properties.Equal(other.Properties) &&
+		m.ReplaceCharsRegex.Equal(other.ReplaceCharsRegex) &&
+		m.Template.Equal(other.Template) &&
+		m.Truncate.Equal(other.Truncate) &&
+		m.Values.Equal(other.Values)
+}
+
+func (r *LabelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_label"
+}
+
+func (r *LabelResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Label resource. Renders a label like the `context_label` data source when it is created and keeps it in state, " +
+			"so later changes to the provider context do not rename the resources that use it. The label is only rendered again when an " +
+			"argument of the resource, such as `values` or `keepers`, changes. When the context would render a different label, the plan " +
+			"shows a warning listing the values that changed, or fails if `prevent_change` is set and the resource is replaced. Import takes an existing label, e.g. " +
+			"`terraform import context_label.bucket cp-core-prod-bucket`. The arguments in the configuration are stored on the first apply after the import " +
+			"without rendering the label again.",
+
+		Attributes: map[string]schema.Attribute{
+			"context": schema.StringAttribute{
+				MarkdownDescription: "A serialized context, like the `encoded` attribute of a `context_child` data source, to create the label from. Defaults to the provider context.",
+				Optional:            true,
+				PlanModifiers:       []planmodifier.String{requiresReplaceUnlessImportedString()},
+			},
+			"delimiter": schema.StringAttribute{
+				MarkdownDescription: "Delimiter to use when creating the label from properties. Conflicts with `template`.",
+				Optional:            true,
+				PlanModifiers:       []planmodifier.String{requiresReplaceUnlessImportedString()},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Set to false to render an empty label without validating the values. Defaults to the provider's `enabled` setting.",
+				Optional:            true,
+				PlanModifiers:       []planmodifier.Bool{requiresReplaceUnlessImportedBool()},
+			},
+			"format": schema.StringAttribute{
+				MarkdownDescription: "The name of a label format from the provider's `label_formats` to render the label with. Conflicts with `delimiter`, `properties` and `template`.",
+				Optional:            true,
+				PlanModifiers:       []planmodifier.String{requiresReplaceUnlessImportedString()},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Label identifier",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"keepers": getKeepersSchema(requiresReplaceUnlessImportedMap()),
+			"max_length": schema.Int64Attribute{
+				MarkdownDescription: "Maximum length of the label",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{requiresReplaceUnlessImportedInt64()},
+			},
+			"prevent_change": schema.BoolAttribute{
+				MarkdownDescription: "Set to true to fail the plan instead of warning when replacing the resource would change the label. Defaults to false.",
+				Optional:            true,
+			},
+			"properties": schema.ListAttribute{
+				MarkdownDescription: "List of properties to use when creating the label. Conflicts with `template`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers:       []planmodifier.List{requiresReplaceUnlessImportedList()},
+			},
+			"rendered": schema.StringAttribute{
+				MarkdownDescription: "Rendered label",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"rendered_values": schema.MapAttribute{
+				MarkdownDescription: "The values of the properties the label was rendered from. Used to explain why a label would change.",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers:       []planmodifier.Map{mapplanmodifier.UseStateForUnknown()},
+			},
+			"replace_chars_regex": schema.StringAttribute{
+				MarkdownDescription: "The regex to use for replacing characters in the label. Any characters that match the regex will be removed from the label.",
+				Optional:            true,
+				PlanModifiers:       []planmodifier.String{requiresReplaceUnlessImportedString()},
+			},
+			"template": schema.StringAttribute{
+				MarkdownDescription: "Template to use when creating the label. Conflicts with `delimiter` and `properties`.",
+				Optional:            true,
+				PlanModifiers:       []planmodifier.String{requiresReplaceUnlessImportedString()},
+			},
+			"truncate": schema.BoolAttribute{
+				MarkdownDescription: "Truncate the label if it exceeds the maximum length. If false, an error will be returned if the label exceeds the maximum length.",
+				Optional:            true,
+				PlanModifiers:       []planmodifier.Bool{requiresReplaceUnlessImportedBool()},
+			},
+			"values": schema.MapAttribute{
+				MarkdownDescription: "Map of values to override or add to the context when creating the label.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers:       []planmodifier.Map{requiresReplaceUnlessImportedMap()},
+			},
+		},
+	}
+}
+
+func (r *LabelResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*model.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *LabelResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("delimiter"),
+			path.MatchRoot("template"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("properties"),
+			path.MatchRoot("template"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("format"),
+			path.MatchRoot("delimiter"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("format"),
+			path.MatchRoot("properties"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("format"),
+			path.MatchRoot("template"),
+		),
+	}
+}
+
+//nolint:gocritic
+func (r *LabelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan LabelResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	label, values, diags := r.renderLabel(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = types.StringValue(stringHelpers.HashString(label))
+	plan.Rendered = types.StringValue(label)
+	plan.RenderedValues, diags = types.MapValueFrom(ctx, types.StringType, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created label resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// renderLabel renders the label from the context, returning the values of the properties that are part of it.
+func (r *LabelResource) renderLabel(ctx context.Context, m *LabelResourceModel) (string, map[string]string, diag.Diagnostics) {
+	pc, diags := getContextProviderConfig(r.providerData, m.Context)
+	if diags.HasError() {
+		return "", nil, diags
+	}
+
+	label, explanation, labelDiags := readLabel(ctx, pc, m.toLabelConfig())
+	diags.Append(labelDiags...)
+	return label.ValueString(), explanation.IncludedValues(), diags
+}
+
+// ModifyPlan renders the label again and compares it with the label in state. When they differ, it warns which values
+// caused the difference. If the resource is replaced, which renders the new label, and prevent_change is set, the
+// warning is an error instead.
+//
+//nolint:gocritic
+func (r *LabelResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing is renamed when the resource is created or destroyed, and nothing can be rendered from unknown arguments
+	// or an unconfigured provider
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() || r.providerData == nil {
+		return
+	}
+
+	var plan, state LabelResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	replacing := !state.isImported() && !plan.hasSameInputs(&state)
+	label, values, diags := r.renderLabel(ctx, &plan)
+	if diags.HasError() {
+		// The label in state is kept unless the resource is replaced, so the errors only matter when it is
+		if replacing {
+			resp.Diagnostics.Append(diags...)
+		}
+		return
+	}
+	if label == state.Rendered.ValueString() {
+		return
+	}
+
+	var stateValues map[string]string
+	if !state.RenderedValues.IsNull() {
+		resp.Diagnostics.Append(state.RenderedValues.ElementsAs(ctx, &stateValues, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	reasons := strings.Join(describeValueChanges(stateValues, values, state.RenderedValues.IsNull()), "; ")
+
+	switch {
+	case !replacing:
+		resp.Diagnostics.AddAttributeWarning(path.Root("rendered"), "Label Differs From Context",
+			fmt.Sprintf("The label is kept as %q, but the context now renders %q because %s. Replacing the resource, e.g. by changing its keepers, would change the label.", state.Rendered.ValueString(), label, reasons))
+	case plan.PreventChange.ValueBool():
+		resp.Diagnostics.AddAttributeError(path.Root("rendered"), "Label Change Prevented",
+			fmt.Sprintf("Replacing the resource would change the label from %q to %q because %s. Set prevent_change to false to allow the change.", state.Rendered.ValueString(), label, reasons))
+	default:
+		resp.Diagnostics.AddAttributeWarning(path.Root("rendered"), "Label Will Change",
+			fmt.Sprintf("Replacing the resource changes the label from %q to %q because %s.", state.Rendered.ValueString(), label, reasons))
+	}
+}
+
+// describeValueChanges returns a description of each value that differs between the values a label was rendered from
+// and the values it would be rendered from now, sorted by property name.
+func describeValueChanges(previous, current map[string]string, imported bool) []string {
+	if imported {
+		return []string{"the label was imported, so the values it was rendered from are not known"}
+	}
+
+	names := make([]string, 0, len(previous)+len(current))
+	for name := range previous {
+		names = append(names, name)
+	}
+	for name := range current {
+		if _, ok := previous[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	changes := []string{}
+	for _, name := range names {
+		before, hadBefore := previous[name]
+		after, hasAfter := current[name]
+		switch {
+		case hadBefore && hasAfter && before != after:
+			changes = append(changes, fmt.Sprintf("%s changed from %q to %q", name, before, after))
+		case !hadBefore:
+			changes = append(changes, fmt.Sprintf("%s was added with %q", name, after))
+		case !hasAfter:
+			changes = append(changes, fmt.Sprintf("%s was removed, it was %q", name, before))
+		}
+	}
+
+	if len(changes) == 0 {
+		return []string{"no property values changed, so the settings of the context, such as the delimiter, property order or label format, changed"}
+	}
+	return changes
+}
+
+// Read keeps the label in state as it is. The label is not rendered again, so it does not follow the provider context.
+//
+//nolint:gocritic
+func (r *LabelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Trace(ctx, "read label resource")
+}
+
+// Update is only called when no argument requiring replacement changed, so the label in state is kept. The first
+// update after an import stores the arguments, and if the context renders the imported label from them, the values it
+// was rendered from, so later changes replace the resource like any other.
+//
+//nolint:gocritic
+func (r *LabelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state LabelResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = state.Id
+	plan.Rendered = state.Rendered
+	plan.RenderedValues = state.RenderedValues
+
+	if state.isImported() && r.providerData != nil {
+		label, values, diags := r.renderLabel(ctx, &plan)
+		if !diags.HasError() && label == state.Rendered.ValueString() {
+			plan.RenderedValues, diags = types.MapValueFrom(ctx, types.StringType, values)
+			resp.Diagnostics.Append(diags...)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the label from state. There is nothing else to clean up.
+//
+//nolint:gocritic
+func (r *LabelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Trace(ctx, "deleted label resource")
+}
+
+// ImportState takes an existing label as the import ID and stores it as the rendered label, so a name created outside
+// of the provider can be kept. The arguments are not known from the label, so setting them on the first plan after the
+// import updates the resource in place instead of replacing it.
+func (r *LabelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("rendered"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), stringHelpers.HashString(req.ID))...)
+}
+
+// getKeepersSchema returns the schema of the keepers of the resources, which replace the resource when they change.
+func getKeepersSchema(requiresReplace planmodifier.Map) schema.MapAttribute {
+	return schema.MapAttribute{
+		MarkdownDescription: "Arbitrary map of values that, when changed, will render the output again. See the random provider's [keepers](https://registry.terraform.io/providers/hashicorp/random/latest/docs#resource-keepers) for more information.",
+		Optional:            true,
+		ElementType:         types.StringType,
+		PlanModifiers:       []planmodifier.Map{requiresReplace},
+	}
+}
+
+// requiresReplaceUnlessImportedDescription describes the plan modifier of the arguments of the label resource.
+const requiresReplaceUnlessImportedDescription = "If the value of this attribute changes, Terraform will destroy and recreate the resource, " +
+	"unless the label was imported and the attribute is set for the first time."
+
+// isImportedLabel returns true when the prior value of an argument is null in the state of an imported label. Setting
+// such an argument must not replace the resource, since that would render the imported label again.
+func isImportedLabel(ctx context.Context, state tfsdk.State, stateValue attr.Value) (bool, diag.Diagnostics) {
+	if state.Raw.IsNull() || !stateValue.IsNull() {
+		return false, nil
+	}
+
+	var renderedValues types.Map
+	diags := state.GetAttribute(ctx, path.Root("rendered_values"), &renderedValues)
+	return renderedValues.IsNull(), diags
+}
+
+// requiresReplaceUnlessImportedString replaces the resource when a string argument changes, unless isImportedLabel.
+func requiresReplaceUnlessImportedString() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+		imported, diags := isImportedLabel(ctx, req.State, req.StateValue)
+		resp.Diagnostics.Append(diags...)
+		resp.RequiresReplace = !imported
+	}, requiresReplaceUnlessImportedDescription, requiresReplaceUnlessImportedDescription)
+}
+
+// requiresReplaceUnlessImportedBool replaces the resource when a bool argument changes, unless isImportedLabel.
+func requiresReplaceUnlessImportedBool() planmodifier.Bool {
+	return boolplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+		imported, diags := isImportedLabel(ctx, req.State, req.StateValue)
+		resp.Diagnostics.Append(diags...)
+		resp.RequiresReplace = !imported
+	}, requiresReplaceUnlessImportedDescription, requiresReplaceUnlessImportedDescription)
+}
+
+// requiresReplaceUnlessImportedInt64 replaces the resource when a number argument changes, unless isImportedLabel.
+func requiresReplaceUnlessImportedInt64() planmodifier.Int64 {
+	return int64planmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+		imported, diags := isImportedLabel(ctx, req.State, req.StateValue)
+		resp.Diagnostics.Append(diags...)
+		resp.RequiresReplace = !imported
+	}, requiresReplaceUnlessImportedDescription, requiresReplaceUnlessImportedDescription)
+}
+
+// requiresReplaceUnlessImportedList replaces the resource when a list argument changes, unless isImportedLabel.
+func requiresReplaceUnlessImportedList() planmodifier.List {
+	return listplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.ListRequest, resp *listplanmodifier.RequiresReplaceIfFuncResponse) {
+		imported, diags := isImportedLabel(ctx, req.State, req.StateValue)
+		resp.Diagnostics.Append(diags...)
+		resp.RequiresReplace = !imported
+	}, requiresReplaceUnlessImportedDescription, requiresReplaceUnlessImportedDescription)
+}
+
+// requiresReplaceUnlessImportedMap replaces the resource when a map argument changes, unless isImportedLabel.
+func requiresReplaceUnlessImportedMap() planmodifier.Map {
+	return mapplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.MapRequest, resp *mapplanmodifier.RequiresReplaceIfFuncResponse) {
+		imported, diags := isImportedLabel(ctx, req.State, req.StateValue)
+		resp.Diagnostics.Append(diags...)
+		resp.RequiresReplace = !imported
+	}, requiresReplaceUnlessImportedDescription, requiresReplaceUnlessImportedDescription)
+}
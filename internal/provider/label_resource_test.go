@@ -0,0 +1,280 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccLabelResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLabelResourceCfg("prod", "a"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("context_label.test", "rendered", "cp-prod-bucket"),
+				),
+			},
+			// A change to the provider context does not change the label
+			{
+				Config: testAccLabelResourceCfg("staging", "a"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("context_label.test", "rendered", "cp-prod-bucket"),
+				),
+			},
+			// A change to the keepers renders the label again
+			{
+				Config: testAccLabelResourceCfg("staging", "b"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("context_label.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("context_label.test", "rendered", "cp-staging-bucket"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLabelResource_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "context" {}
+
+resource "context_label" "test" {}`,
+				ResourceName:       "context_label.test",
+				ImportState:        true,
+				ImportStateId:      "legacy-bucket-name",
+				ImportStatePersist: true,
+			},
+			{
+				Config: `
+provider "context" {}
+
+resource "context_label" "test" {}`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("context_label.test", "rendered", "legacy-bucket-name"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLabelResource_importWithArguments(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:             testAccLabelResourceCfg("prod", "a"),
+				ResourceName:       "context_label.test",
+				ImportState:        true,
+				ImportStateId:      "cp-prod-bucket",
+				ImportStatePersist: true,
+			},
+			// The arguments are stored without rendering the imported label again
+			{
+				Config: testAccLabelResourceCfg("prod", "a"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("context_label.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("context_label.test", "rendered", "cp-prod-bucket"),
+					resource.TestCheckResourceAttr("context_label.test", "keepers.version", "a"),
+					resource.TestCheckResourceAttr("context_label.test", "rendered_values.stage", "prod"),
+				),
+			},
+			// Once stored, a change to the arguments replaces the resource
+			{
+				Config: testAccLabelResourceCfg("staging", "b"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("context_label.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("context_label.test", "rendered", "cp-staging-bucket"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLabelResource_preventChange(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLabelResourceCfg("prod", "a") + testAccLabelResourcePreventChangeCfg("a"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("context_label.protected", "rendered", "cp-prod-db"),
+					resource.TestCheckResourceAttr("context_label.protected", "rendered_values.stage", "prod"),
+				),
+			},
+			// The label is kept, so a change to the context only warns
+			{
+				Config: testAccLabelResourceCfg("staging", "a") + testAccLabelResourcePreventChangeCfg("a"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Replacing the resource would rename it
+			{
+				Config:      testAccLabelResourceCfg("staging", "a") + testAccLabelResourcePreventChangeCfg("b"),
+				ExpectError: regexp.MustCompile(`(?s)Label Change Prevented.*stage changed from "prod" to "staging"`),
+			},
+		},
+	})
+}
+
+func TestDescribeValueChanges(t *testing.T) {
+	assert.Equal(t, []string{
+		`name was added with "api"`,
+		`stage changed from "prod" to "staging"`,
+		`tenant was removed, it was "core"`,
+	}, describeValueChanges(
+		map[string]string{"namespace": "cp", "stage": "prod", "tenant": "core"},
+		map[string]string{"namespace": "cp", "stage": "staging", "name": "api"},
+		false,
+	))
+
+	changes := describeValueChanges(map[string]string{"stage": "prod"}, map[string]string{"stage": "prod"}, false)
+	assert.Len(t, changes, 1)
+	assert.Contains(t, changes[0], "settings of the context")
+
+	changes = describeValueChanges(nil, map[string]string{"stage": "prod"}, true)
+	assert.Len(t, changes, 1)
+	assert.Contains(t, changes[0], "imported")
+}
+
+func testAccLabelResourcePreventChangeCfg(keeper string) string {
+	return fmt.Sprintf(`
+resource "context_label" "protected" {
+  prevent_change = true
+
+  values = {
+    name = "db"
+  }
+
+  keepers = {
+    version = %q
+  }
+}
+`, keeper)
+}
+
+func testAccLabelResourceCfg(stage, keeper string) string {
+	return fmt.Sprintf(`
+provider "context" {
+  properties = {
+    namespace = { order = 1 }
+    stage     = { order = 2 }
+    name      = { order = 3 }
+  }
+
+  values = {
+    namespace = "cp"
+    stage     = %q
+  }
+}
+
+resource "context_label" "test" {
+  values = {
+    name = "bucket"
+  }
+
+  keepers = {
+    version = %q
+  }
+}
+`, stage, keeper)
+}
+
+func TestLabelResourceRequiresReplaceAfterImport(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewLabelResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	newModel := func(renderedValues types.Map, values types.Map) *LabelResourceModel {
+		return &LabelResourceModel{
+			Id:             types.StringValue("id"),
+			Keepers:        types.MapNull(types.StringType),
+			Properties:     types.ListNull(types.StringType),
+			Rendered:       types.StringValue("legacy-bucket-name"),
+			RenderedValues: renderedValues,
+			Values:         values,
+		}
+	}
+	imported := types.MapNull(types.StringType)
+	created := types.MapValueMust(types.StringType, map[string]attr.Value{})
+	noValues := types.MapNull(types.StringType)
+	someValues := types.MapValueMust(types.StringType, map[string]attr.Value{"name": types.StringValue("bucket")})
+	otherValues := types.MapValueMust(types.StringType, map[string]attr.Value{"name": types.StringValue("logs")})
+
+	testCases := map[string]struct {
+		state    *LabelResourceModel
+		plan     *LabelResourceModel
+		expected bool
+	}{
+		"set after import":     {state: newModel(imported, noValues), plan: newModel(imported, someValues), expected: false},
+		"changed after import": {state: newModel(imported, someValues), plan: newModel(imported, otherValues), expected: true},
+		"set after create":     {state: newModel(created, noValues), plan: newModel(created, someValues), expected: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			assert.False(t, state.Set(ctx, tc.state).HasError())
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			assert.False(t, plan.Set(ctx, tc.plan).HasError())
+
+			req := planmodifier.MapRequest{
+				Path:        path.Root("values"),
+				State:       state,
+				StateValue:  tc.state.Values,
+				Plan:        plan,
+				PlanValue:   tc.plan.Values,
+				ConfigValue: tc.plan.Values,
+			}
+			resp := &planmodifier.MapResponse{PlanValue: tc.plan.Values}
+			requiresReplaceUnlessImportedMap().PlanModifyMap(ctx, req, resp)
+
+			assert.False(t, resp.Diagnostics.HasError())
+			assert.Equal(t, tc.expected, resp.RequiresReplace)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudposse/terraform-provider-context/internal/model"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource              = &LookupDataSource{}
+	_ datasource.DataSourceWithConfigure = &LookupDataSource{}
+)
+
+func NewLookupDataSource() datasource.DataSource {
+	return &LookupDataSource{}
+}
+
+// LookupDataSource defines the data source implementation.
+type LookupDataSource struct {
+	providerData *model.ProviderData
+}
+
+// LookupDataSourceModel describes the data source data model.
+type LookupDataSourceModel struct {
+	Context types.String `tfsdk:"context"`
+	Id      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Strict  types.Bool   `tfsdk:"strict"`
+	Values  types.Map    `tfsdk:"values"`
+}
+
+func (d *LookupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_lookup"
+}
+
+func (d *LookupDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lookup data source. Returns a whole lookup table, either one of the `lookups` of the context or one of the built-in region catalogs. " +
+			"The built-in catalogs abbreviate the regions of AWS, Azure and GCP in the `short` style, e.g. `uw2` for `us-west-2`, and the `fixed` style, where every code of a cloud has the same length, e.g. `usw2`. The AWS codes are those of cloudposse's terraform-aws-utils, the Azure short codes are Microsoft's Azure Backup geo-codes and the other codes abbreviate the location, direction and number of the region. " +
+			"They are named after the cloud and the style: " + strings.Join(model.GetBuiltinLookupNames(), ", ") + ".",
+
+		Attributes: map[string]schema.Attribute{
+			"context": getContextDSSchema("The serialized context to read the lookup from, e.g. the `encoded` attribute of `context_child`. Defaults to the provider context."),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The name of the lookup.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the lookup, e.g. `aws_region_short`. A lookup of the context takes precedence over a built-in lookup with the same name.",
+				Required:            true,
+			},
+			"strict": schema.BoolAttribute{
+				MarkdownDescription: "Whether a value that is not in the lookup is an error. Built-in lookups are not strict.",
+				Computed:            true,
+			},
+			"values": schema.MapAttribute{
+				MarkdownDescription: "A map of the values of a property to the values that replace them.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *LookupDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*model.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+//nolint:gocritic
+func (d *LookupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config LookupDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pc, diags := getContextProviderConfig(d.providerData, config.Context)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lookup, err := pc.GetLookup(config.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Unknown Lookup", err.Error())
+		return
+	}
+
+	values, diags := types.MapValueFrom(ctx, types.StringType, lookup.Values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.Values = values
+	config.Strict = types.BoolValue(lookup.Strict)
+	config.Id = config.Name
+
+	tflog.Trace(ctx, "read lookup data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
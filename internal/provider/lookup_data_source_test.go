@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccLookupDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "context" {
+  lookups = {
+    stages = {
+      strict = true
+      values = {
+        production = "prod"
+      }
+    }
+  }
+}
+
+data "context_lookup" "aws" {
+  name = "aws_region_short"
+}
+
+data "context_lookup" "fixed" {
+  name = "aws_region_fixed"
+}
+
+data "context_lookup" "azure" {
+  name = "azure_region_short"
+}
+
+data "context_lookup" "gcp" {
+  name = "gcp_region_fixed"
+}
+
+data "context_lookup" "stages" {
+  name = "stages"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_lookup.aws", "id", "aws_region_short"),
+					resource.TestCheckResourceAttr("data.context_lookup.aws", "values.us-west-2", "uw2"),
+					resource.TestCheckResourceAttr("data.context_lookup.aws", "strict", "false"),
+					resource.TestCheckResourceAttr("data.context_lookup.fixed", "values.us-west-2", "usw2"),
+					resource.TestCheckResourceAttr("data.context_lookup.azure", "values.westeurope", "we"),
+					resource.TestCheckResourceAttr("data.context_lookup.gcp", "values.us-central1", "usce01"),
+					resource.TestCheckResourceAttr("data.context_lookup.stages", "values.%", "1"),
+					resource.TestCheckResourceAttr("data.context_lookup.stages", "strict", "true"),
+				),
+			},
+			{
+				Config: `
+provider "context" {}
+
+data "context_lookup" "test" {
+  name = "aws_regions"
+}`,
+				ExpectError: regexp.MustCompile(`(?s)Error: Unknown Lookup.*unknown lookup: "aws_regions"`),
+			},
+		},
+	})
+}
+
+func TestAccLookupDataSource_builtinLabelLookup(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "context" {
+  properties = {
+    namespace = { order = 1 }
+    region    = { order = 2, label_lookup = "aws_region_fixed" }
+  }
+
+  values = {
+    namespace = "cp"
+    region    = "eu-central-1"
+  }
+}
+
+data "context_label" "test" {}
+
+data "context_tags" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_label.test", "rendered", "cp-euc1"),
+					resource.TestCheckResourceAttr("data.context_tags.test", "tags.Region", "eu-central-1"),
+				),
+			},
+		},
+	})
+}
@@ -2,11 +2,19 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
 
+	"github.com/cloudposse/terraform-provider-context/internal/framework"
 	"github.com/cloudposse/terraform-provider-context/internal/model"
 	"github.com/cloudposse/terraform-provider-context/pkg/cases"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -16,7 +24,10 @@ import (
 )
 
 // Ensure ContextProvider satisfies various provider interfaces.
-var _ provider.Provider = &ContextProvider{}
+var (
+	_ provider.Provider              = &ContextProvider{}
+	_ provider.ProviderWithFunctions = &ContextProvider{}
+)
 
 // ContextProvider defines the provider implementation.
 type ContextProvider struct {
@@ -28,14 +39,22 @@ type ContextProvider struct {
 
 // ContextProviderModel describes the provider data model.
 type providerConfigModel struct {
-	Delimiter         types.String `tfsdk:"delimiter"`
-	Enabled           types.Bool   `tfsdk:"enabled"`
-	Properties        types.Map    `tfsdk:"properties"`
-	PropertyOrder     types.List   `tfsdk:"property_order"`
-	ReplaceCharsRegex types.String `tfsdk:"replace_chars_regex"`
-	TagsKeyCase       types.String `tfsdk:"tags_key_case"`
-	TagsValueCase     types.String `tfsdk:"tags_value_case"`
-	Values            types.Map    `tfsdk:"values"`
+	ConfigFile        types.String  `tfsdk:"config_file"`
+	ContextToken      types.String  `tfsdk:"context_token"`
+	Delimiter         types.String  `tfsdk:"delimiter"`
+	Enabled           types.Bool    `tfsdk:"enabled"`
+	LabelFormats      types.Map     `tfsdk:"label_formats"`
+	Lookups           types.Map     `tfsdk:"lookups"`
+	NullLabelContext  types.Dynamic `tfsdk:"null_label_context"`
+	Properties        types.Map     `tfsdk:"properties"`
+	PropertyOrder     types.List    `tfsdk:"property_order"`
+	ReplaceCharsRegex types.String  `tfsdk:"replace_chars_regex"`
+	Strict            types.Bool    `tfsdk:"strict"`
+	TagsKeyCase       types.String  `tfsdk:"tags_key_case"`
+	TagsValueCase     types.String  `tfsdk:"tags_value_case"`
+	Validations       types.List    `tfsdk:"validations"`
+	Values            types.Map     `tfsdk:"values"`
+	ValuesEnvPrefix   types.String  `tfsdk:"values_env_prefix"`
 }
 
 func (p *ContextProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -46,6 +65,15 @@ func (p *ContextProvider) Metadata(ctx context.Context, req provider.MetadataReq
 func (p *ContextProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"config_file": schema.StringAttribute{
+				MarkdownDescription: "The path to a YAML or JSON file with the context to load. The file accepts the same settings as the provider block, which take precedence over the file. Can also be set with the `" + ConfigFileEnvVar + "` environment variable.",
+				Optional:            true,
+			},
+			"context_token": schema.StringAttribute{
+				MarkdownDescription: "A context serialized as a token by the `encoded` attribute of `context_config`, e.g. in another root module, to rebuild the context from. " +
+					"All other settings take precedence over the token.",
+				Optional: true,
+			},
 			"delimiter": schema.StringAttribute{
 				MarkdownDescription: "The default delimiter to use for labels created by the provider.",
 				Optional:            true,
@@ -54,6 +82,27 @@ func (p *ContextProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				MarkdownDescription: "A boolean value to enable or disable the provider.",
 				Optional:            true,
 			},
+			"label_formats": schema.MapNestedAttribute{
+				MarkdownDescription: "A map of named label formats. Each format is either delimited, built from `properties` and `delimiter`, or a `template`. Use a format with the `format` attribute of `context_label`.",
+				Optional:            true,
+				NestedObject:        getLabelFormatsSchema(),
+			},
+			"lookups": schema.MapNestedAttribute{
+				MarkdownDescription: "A map of named lookup tables that replace the values of properties when they are rendered, such as abbreviations of regions and stages. " +
+					"A property uses a lookup in labels with `label_lookup` and in tags with `tag_lookup`, so labels can use `uw2` while tags keep `us-west-2`. " +
+					"Lookups are merged by name with the lookups of the context file and `context_token`, and replace the built-in region catalogs with the same name, such as `aws_region_short`. See `context_lookup` for the built-in catalogs.",
+				Optional:     true,
+				NestedObject: getLookupsSchema(),
+			},
+			"null_label_context": schema.DynamicAttribute{
+				MarkdownDescription: "A terraform-null-label `context` object, e.g. `module.this.context`, to import as the context. " +
+					"Its labels become properties and values, `label_order` becomes the property order and `descriptor_formats` become label formats. " +
+					"Like null-label, `regex_replace_chars` and `label_value_case`, or their defaults, are applied to each imported label, so the values render the same id. " +
+					"They are not applied to values from other sources. `label_key_case` and `label_value_case` also become the tag cases. " +
+					"Fields without an equivalent, such as `tags`, `additional_tag_map` and `id_length_limit`, produce warnings. " +
+					"Settings in the provider block and the environment take precedence over the object, which takes precedence over `config_file`.",
+				Optional: true,
+			},
 			"properties": schema.MapNestedAttribute{
 				MarkdownDescription: "A map of properties to use for labels created by the provider.",
 				Optional:            true,
@@ -68,6 +117,12 @@ func (p *ContextProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				MarkdownDescription: "The regex to use for replacing characters in labels created by the provider. Any characters that match the regex will be removed from the label.",
 				Optional:            true,
 			},
+			"strict": schema.BoolAttribute{
+				MarkdownDescription: "Set to true to report problems with the configuration of the context as errors instead of warnings. " +
+					"The provider checks that every regex compiles, that `property_order` entries and `values` keys, other than those read from the environment, name declared properties, " +
+					"that no property has a `min_length` greater than its `max_length` and that no two properties have the same tag key after case conversion.",
+				Optional: true,
+			},
 			"tags_key_case": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "The case to use for the keys of tags created by the provider. Valid values are: none, camel, lower, snake, title, upper.",
@@ -82,11 +137,21 @@ func (p *ContextProvider) Schema(ctx context.Context, req provider.SchemaRequest
 					stringvalidator.OneOf(ValidCases...),
 				},
 			},
+			"validations": schema.ListNestedAttribute{
+				MarkdownDescription: "A list of validation rules for constraints that span several properties, such as a budget for the combined length of `namespace` and `name`. " +
+					"Rules run after the checks of each property, whenever the values are validated. Rules are merged by name with the rules of the context file and `context_token`.",
+				Optional:     true,
+				NestedObject: getValidationsSchema(),
+			},
 			"values": schema.MapAttribute{
-				MarkdownDescription: "A map of values to use for labels created by the provider.",
+				MarkdownDescription: "A map of values to use for labels created by the provider. Values are merged from, in increasing order of precedence: `context_token`, the context file, `null_label_context`, environment variables starting with `values_env_prefix` and this map.",
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
+			"values_env_prefix": schema.StringAttribute{
+				MarkdownDescription: "The prefix of environment variables to read values from, e.g. `CONTEXT_VALUE_`. The rest of the variable name is the value's key, e.g. `CONTEXT_VALUE_stage` sets the `stage` value. Values are only read from the environment when this is set to a non-empty prefix.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -98,8 +163,16 @@ func (p *ContextProvider) getConfigProperties(ctx context.Context, providerConfi
 		return nil
 	}
 
+	// Read the properties in a stable order so the resulting config does not depend on map iteration order
+	names := make([]string, 0, len(properties))
+	for k := range properties {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
 	configProperties := []model.Property{}
-	for k, prop := range properties {
+	for _, k := range names {
+		prop := properties[k]
 		property, err := prop.ToModel(k)
 		if err != nil {
 			resp.Diagnostics.AddError("Failed to convert property to model", err.Error())
@@ -128,6 +201,67 @@ func (p *ContextProvider) getValues(ctx context.Context, providerConfigModel *pr
 	return values
 }
 
+func (p *ContextProvider) getLabelFormats(ctx context.Context, providerConfigModel *providerConfigModel, resp *provider.ConfigureResponse) map[string]model.LabelFormat {
+	frameworkFormats := map[string]model.FrameworkLabelFormat{}
+	resp.Diagnostics.Append(providerConfigModel.LabelFormats.ElementsAs(ctx, &frameworkFormats, false)...)
+	if resp.Diagnostics.HasError() {
+		return nil
+	}
+
+	formats := make(map[string]model.LabelFormat, len(frameworkFormats))
+	for name, frameworkFormat := range frameworkFormats {
+		format, diags := frameworkFormat.ToModel(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return nil
+		}
+		if err := format.Validate(); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("label_formats").AtMapKey(name), "Invalid Label Format", err.Error())
+			return nil
+		}
+		formats[name] = format
+	}
+	return formats
+}
+
+func (p *ContextProvider) getLookups(ctx context.Context, providerConfigModel *providerConfigModel, resp *provider.ConfigureResponse) map[string]model.Lookup {
+	frameworkLookups := map[string]model.FrameworkLookup{}
+	resp.Diagnostics.Append(providerConfigModel.Lookups.ElementsAs(ctx, &frameworkLookups, false)...)
+	if resp.Diagnostics.HasError() {
+		return nil
+	}
+
+	lookups := make(map[string]model.Lookup, len(frameworkLookups))
+	for name, frameworkLookup := range frameworkLookups {
+		lookup, diags := frameworkLookup.ToModel(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return nil
+		}
+		lookups[name] = lookup
+	}
+	return lookups
+}
+
+func (p *ContextProvider) getValidationRules(ctx context.Context, providerConfigModel *providerConfigModel, resp *provider.ConfigureResponse) []model.ValidationRule {
+	frameworkRules := []model.FrameworkValidationRule{}
+	resp.Diagnostics.Append(providerConfigModel.Validations.ElementsAs(ctx, &frameworkRules, false)...)
+	if resp.Diagnostics.HasError() {
+		return nil
+	}
+
+	rules := make([]model.ValidationRule, 0, len(frameworkRules))
+	for i, frameworkRule := range frameworkRules {
+		rule := frameworkRule.ToModel()
+		if err := rule.Validate(); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("validations").AtListIndex(i), "Invalid Validation Rule", err.Error())
+			return nil
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
 func (p *ContextProvider) getOptions(providerConfigModel *providerConfigModel, resp *provider.ConfigureResponse) []func(*model.ProviderConfig) {
 	options := []func(*model.ProviderConfig){}
 
@@ -164,17 +298,27 @@ func (p *ContextProvider) getOptions(providerConfigModel *providerConfigModel, r
 	return options
 }
 
-func (p *ContextProvider) createAndValidateProviderConfig(configProperties []model.Property, propertyOrder []string, values map[string]string, options []func(*model.ProviderConfig), resp *provider.ConfigureResponse) *model.ProviderData {
-	providerConfig, err := model.NewProviderConfig(configProperties, propertyOrder, values, options...)
+// createAndValidateProviderConfig creates the provider config from the merged layer, checks its configuration and
+// validates its values. Problems with the values and properties set in the provider block are reported on those
+// attributes.
+func (p *ContextProvider) createAndValidateProviderConfig(layer, inline configLayer, providerConfigModel *providerConfigModel, resp *provider.ConfigureResponse) *model.ProviderData {
+	providerConfig, err := model.NewProviderConfig(layer.properties, layer.propertyOrder, layer.values, layer.options...)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create provider config", err.Error())
 		return nil
 	}
 
-	if errs := providerConfig.ValidateProperties(values); len(errs) > 0 {
-		for _, err := range errs {
-			resp.Diagnostics.AddError("Validation Error", err.Error())
-		}
+	// A disabled context renders nothing, so its values are not validated. Values are validated after defaults and
+	// derived values are filled in.
+	validationErrs := []error{}
+	if providerConfig.IsEnabled() {
+		resolved, errs := providerConfig.ResolveValues(nil)
+		validationErrs = append(errs, providerConfig.ValidateProperties(resolved)...)
+	}
+
+	addLintDiagnostics(providerConfig.Lint(), validationErrs, &resp.Diagnostics, inline, !providerConfigModel.ReplaceCharsRegex.IsNull(), providerConfigModel.Strict.ValueBool())
+	addValidationErrors(validationErrs, &resp.Diagnostics, inline.values, inline.propertyNames())
+	if resp.Diagnostics.HasError() {
 		return nil
 	}
 
@@ -183,6 +327,131 @@ func (p *ContextProvider) createAndValidateProviderConfig(configProperties []mod
 	}
 }
 
+// getContextTokenLayer decodes the context token set in the provider configuration.
+func (p *ContextProvider) getContextTokenLayer(providerConfigModel *providerConfigModel, resp *provider.ConfigureResponse) configLayer {
+	if providerConfigModel.ContextToken.IsNull() {
+		return configLayer{}
+	}
+
+	pc, err := model.DecodeProviderConfig(providerConfigModel.ContextToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("context_token"), "Invalid Context Token", err.Error())
+		return configLayer{}
+	}
+
+	return newProviderConfigLayer(pc, model.ValueSourceContextToken)
+}
+
+// getContextFileLayer loads the context file set in the provider configuration or in the environment.
+func (p *ContextProvider) getContextFileLayer(providerConfigModel *providerConfigModel, resp *provider.ConfigureResponse) configLayer {
+	configFile := providerConfigModel.ConfigFile.ValueString()
+	if providerConfigModel.ConfigFile.IsNull() {
+		configFile, _ = os.LookupEnv(ConfigFileEnvVar)
+	}
+	if configFile == "" {
+		return configLayer{}
+	}
+
+	contextFile, err := model.LoadContextFile(configFile)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("config_file"), "Invalid Context File", err.Error())
+		return configLayer{}
+	}
+
+	return newContextFileLayer(contextFile, model.ValueSourceConfigFile)
+}
+
+// getNullLabelContextLayer converts the null-label context object set in the provider configuration. Fields that
+// cannot be converted are reported as warnings.
+func (p *ContextProvider) getNullLabelContextLayer(providerConfigModel *providerConfigModel, resp *provider.ConfigureResponse) configLayer {
+	attributePath := path.Root("null_label_context")
+	if providerConfigModel.NullLabelContext.IsNull() {
+		return configLayer{}
+	}
+
+	value, err := framework.FromFrameworkValue(providerConfigModel.NullLabelContext)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(attributePath, "Invalid null-label Context", err.Error())
+		return configLayer{}
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(attributePath, "Invalid null-label Context", err.Error())
+		return configLayer{}
+	}
+
+	nullLabelContext, warnings, err := model.ParseNullLabelContext(data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(attributePath, "Invalid null-label Context", err.Error())
+		return configLayer{}
+	}
+	contextFile, conversionWarnings := nullLabelContext.ToContextFile()
+
+	for _, warning := range append(warnings, conversionWarnings...) {
+		resp.Diagnostics.AddAttributeWarning(attributePath, "Unsupported null-label Context Field", fmt.Sprintf("%s %s.", warning.Field, warning.Message))
+	}
+
+	return newContextFileLayer(contextFile, model.ValueSourceNullLabelContext)
+}
+
+// getEnvironmentLayer reads the values set in environment variables starting with the configured prefix. Nothing is
+// read unless a prefix is configured.
+func (p *ContextProvider) getEnvironmentLayer(providerConfigModel *providerConfigModel) configLayer {
+	prefix := providerConfigModel.ValuesEnvPrefix.ValueString()
+	if prefix == "" {
+		return configLayer{}
+	}
+
+	values := map[string]string{}
+	for _, env := range os.Environ() {
+		name, value, found := strings.Cut(env, "=")
+		if !found || !strings.HasPrefix(name, prefix) || len(name) == len(prefix) {
+			continue
+		}
+		values[strings.TrimPrefix(name, prefix)] = value
+	}
+
+	return configLayer{values: values}.withValueSource(model.ValueSourceEnvironment)
+}
+
+// getInlineLayer reads the settings of the provider block.
+func (p *ContextProvider) getInlineLayer(ctx context.Context, providerConfigModel *providerConfigModel, resp *provider.ConfigureResponse) configLayer {
+	var layer configLayer
+
+	layer.properties = p.getConfigProperties(ctx, providerConfigModel, resp)
+	if resp.Diagnostics.HasError() {
+		return layer
+	}
+
+	layer.propertyOrder = p.getPropertyOrder(ctx, providerConfigModel, resp)
+	if resp.Diagnostics.HasError() {
+		return layer
+	}
+
+	layer.values = p.getValues(ctx, providerConfigModel, resp)
+	if resp.Diagnostics.HasError() {
+		return layer
+	}
+
+	labelFormats := p.getLabelFormats(ctx, providerConfigModel, resp)
+	if resp.Diagnostics.HasError() {
+		return layer
+	}
+
+	lookups := p.getLookups(ctx, providerConfigModel, resp)
+	if resp.Diagnostics.HasError() {
+		return layer
+	}
+
+	validationRules := p.getValidationRules(ctx, providerConfigModel, resp)
+	if resp.Diagnostics.HasError() {
+		return layer
+	}
+
+	layer.options = append(p.getOptions(providerConfigModel, resp), model.WithLabelFormats(labelFormats), model.WithLookups(lookups), model.WithValidationRules(validationRules))
+	return layer.withValueSource(model.ValueSourceProvider)
+}
+
 func (p *ContextProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var providerConfigModel providerConfigModel
 
@@ -191,38 +460,47 @@ func (p *ContextProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
-	configProperties := p.getConfigProperties(ctx, &providerConfigModel, resp)
+	tokenLayer := p.getContextTokenLayer(&providerConfigModel, resp)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	propertyOrder := p.getPropertyOrder(ctx, &providerConfigModel, resp)
+	fileLayer := p.getContextFileLayer(&providerConfigModel, resp)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	values := p.getValues(ctx, &providerConfigModel, resp)
+	nullLabelLayer := p.getNullLabelContextLayer(&providerConfigModel, resp)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	options := p.getOptions(&providerConfigModel, resp)
+	inlineLayer := p.getInlineLayer(ctx, &providerConfigModel, resp)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Settings in the provider block take precedence over the environment, which takes precedence over the null-label
+	// context, which takes precedence over the context file, which takes precedence over the context token
+	layer := tokenLayer.overrideWith(fileLayer).overrideWith(nullLabelLayer).overrideWith(p.getEnvironmentLayer(&providerConfigModel)).overrideWith(inlineLayer)
+	layer.options = append(layer.options, model.WithValueSources(layer.valueSources))
+
 	tflog.Debug(ctx, "Data received from the configuration", map[string]any{
+		"config_file":         providerConfigModel.ConfigFile.ValueString(),
 		"delimiter":           providerConfigModel.Delimiter.ValueString(),
 		"enabled":             providerConfigModel.Enabled.ValueBool(),
-		"properties":          configProperties,
-		"property_order":      propertyOrder,
+		"label_formats":       providerConfigModel.LabelFormats.String(),
+		"properties":          layer.properties,
+		"property_order":      layer.propertyOrder,
 		"replace_chars_regex": providerConfigModel.ReplaceCharsRegex.ValueString(),
 		"tags_key_case":       providerConfigModel.TagsKeyCase.ValueString(),
 		"tags_value_case":     providerConfigModel.TagsValueCase.ValueString(),
-		"values":              values,
+		"validations":         providerConfigModel.Validations.String(),
+		"values":              layer.values,
+		"value_sources":       layer.valueSources,
 	})
 
-	providerData := p.createAndValidateProviderConfig(configProperties, propertyOrder, values, options, resp)
+	providerData := p.createAndValidateProviderConfig(layer, inlineLayer, &providerConfigModel, resp)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -235,17 +513,32 @@ func (p *ContextProvider) Configure(ctx context.Context, req provider.ConfigureR
 }
 
 func (p *ContextProvider) Resources(ctx context.Context) []func() resource.Resource {
-	return nil
+	return []func() resource.Resource{
+		NewLabelResource,
+		NewTagsResource,
+	}
 }
 
 func (p *ContextProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewChildDataSource,
 		NewConfigDataSource,
 		NewLabelDataSource,
+		NewLookupDataSource,
 		NewTagsDataSource,
 	}
 }
 
+func (p *ContextProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewLabelFunction,
+		NewTagsFunction,
+		NewApplyCaseFunction,
+		NewSanitizeFunction,
+		NewTruncateWithHashFunction,
+	}
+}
+
 func NewProvider(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &ContextProvider{
@@ -1,9 +1,13 @@
 package provider
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
+	"github.com/cloudposse/terraform-provider-context/internal/model"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -93,7 +97,466 @@ provider "context" {
 }
 
 data "context_config" "test" {}`,
-				ExpectError: regexp.MustCompile(`(?s)Error: Validation Error.*with provider\["registry.terraform.io/hashicorp/context"\].*regex is invalid: \[ for property namespace`),
+				ExpectError: regexp.MustCompile(`(?s)Error: Invalid Validation Regex.*with provider\["registry.terraform.io/hashicorp/context"\].*regex is invalid: \[ for property namespace`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_strict(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Without strict, problems with the configuration are only warnings
+				Config: `
+provider "context" {
+  property_order = ["namespace", "environment"]
+
+  properties = {
+    namespace = {}
+  }
+
+  values = {
+    namespace = "cp"
+  }
+}
+
+data "context_config" "test" {}`,
+				Check: resource.TestCheckResourceAttr("data.context_config.test", "values.namespace", "cp"),
+			},
+			{
+				Config: `
+provider "context" {
+  strict = true
+  property_order = ["namespace", "environment"]
+
+  properties = {
+    namespace = {}
+  }
+
+  values = {
+    namespace = "cp"
+  }
+}
+
+data "context_config" "test" {}`,
+				ExpectError: regexp.MustCompile(`(?s)Error: Undeclared Property In Property Order.*property is not declared: property order entry\s+environment`),
+			},
+			{
+				Config: `
+provider "context" {
+  strict = true
+
+  properties = {
+    namespace = {
+      min_length = 5
+      max_length = 3
+    }
+  }
+}
+
+data "context_config" "test" {}`,
+				ExpectError: regexp.MustCompile(`(?s)Error: Invalid Length Range.*5 is greater than 3 for property\s+namespace`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_validations(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "context" {
+  properties = {
+    namespace = {}
+    name      = {}
+  }
+
+  validations = [
+    {
+      name          = "name_length"
+      condition     = "{{ le (len (print .namespace .name)) 10 }}"
+      error_message = "{{.namespace}} and {{.name}} are longer than 10 characters"
+    },
+  ]
+
+  values = {
+    namespace = "cp"
+  }
+}
+
+data "context_label" "test" {
+  values = {
+    name = "app"
+  }
+}`,
+				Check: resource.TestCheckResourceAttr("data.context_label.test", "rendered", "cp-app"),
+			},
+			{
+				Config: `
+provider "context" {
+  properties = {
+    namespace = {}
+    name      = {}
+  }
+
+  validations = [
+    {
+      name          = "name_length"
+      condition     = "{{ le (len (print .namespace .name)) 10 }}"
+      error_message = "{{.namespace}} and {{.name}} are longer than 10 characters"
+    },
+  ]
+
+  values = {
+    namespace = "cp"
+  }
+}
+
+data "context_label" "test" {
+  values = {
+    name = "application"
+  }
+}`,
+				ExpectError: regexp.MustCompile(`(?s)Error: Validation Rule Failed.*validation rule failed: name_length: cp and application are longer than\s+10\s+characters`),
+			},
+			{
+				Config: `
+provider "context" {
+  validations = [
+    {
+      name      = "invalid"
+      condition = "{{ .namespace"
+    },
+  ]
+}
+
+data "context_config" "test" {}`,
+				ExpectError: regexp.MustCompile(`(?s)Error: Invalid Validation Rule.*condition of rule invalid`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_lookups(t *testing.T) {
+	providerCfg := func(strict bool) string {
+		return fmt.Sprintf(`
+provider "context" {
+  properties = {
+    namespace = { order = 1 }
+    region    = { order = 2, label_lookup = "regions" }
+    stage     = { order = 3, label_lookup = "stages", tag_lookup = "stages" }
+  }
+
+  lookups = {
+    regions = {
+      strict = %t
+      values = {
+        "us-west-2" = "uw2"
+      }
+    }
+    stages = {
+      values = {
+        production = "prod"
+      }
+    }
+  }
+
+  values = {
+    namespace = "cp"
+    stage     = "production"
+  }
+}
+`, strict)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerCfg(true) + `
+data "context_label" "test" {
+  values = {
+    region = "us-west-2"
+  }
+}
+
+data "context_tags" "test" {
+  values = {
+    region = "us-west-2"
+  }
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_label.test", "rendered", "cp-uw2-prod"),
+					resource.TestCheckResourceAttr("data.context_tags.test", "tags.Region", "us-west-2"),
+					resource.TestCheckResourceAttr("data.context_tags.test", "tags.Stage", "prod"),
+				),
+			},
+			{
+				Config: providerCfg(false) + `
+data "context_label" "test" {
+  values = {
+    region = "ap-south-1"
+  }
+}`,
+				Check: resource.TestCheckResourceAttr("data.context_label.test", "rendered", "cp-ap-south-1-prod"),
+			},
+			{
+				Config: providerCfg(true) + `
+data "context_label" "test" {
+  values = {
+    region = "ap-south-1"
+  }
+}`,
+				ExpectError: regexp.MustCompile(`(?s)Error: Value Not In Lookup.*ap-south-1 for property region in lookup\s+regions`),
+			},
+			{
+				Config: `
+provider "context" {
+  properties = {
+    region = { label_lookup = "regions" }
+  }
+}
+
+data "context_config" "test" {}`,
+				ExpectError: regexp.MustCompile(`unknown lookup`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_configFile(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "context.yaml")
+	err := os.WriteFile(configFile, []byte(`
+delimiter: "_"
+properties:
+  namespace:
+    required: true
+    order: 1
+  stage:
+    order: 2
+  name:
+    order: 3
+values:
+  namespace: cp
+  stage: dev
+  name: example
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "context" {
+  config_file = %q
+
+  values = {
+    stage = "prod"
+  }
+}
+
+data "context_label" "test" {}`, configFile),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_label.test", "rendered", "cp_prod_example"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_invalidConfigFile(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "context.yaml")
+	if err := os.WriteFile(configFile, []byte("properties:\n  namespace:\n    requried: true\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "context" {
+  config_file = %q
+}
+
+data "context_config" "test" {}`, configFile),
+				ExpectError: regexp.MustCompile(`(?s)Invalid Context File.*properties\.namespace\.requried \(line 3\): unknown key`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_invalidLabelFormat(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "context" {
+  label_formats = {
+    stack = {
+      delimiter = "-"
+      template  = "{{.stage}}"
+    }
+  }
+}
+
+data "context_config" "test" {}`,
+				ExpectError: regexp.MustCompile(`(?s)Invalid Label Format.*template conflicts with delimiter and properties`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_environmentValues(t *testing.T) {
+	t.Setenv("CONTEXT_VALUE_stage", "staging")
+	t.Setenv("CONTEXT_VALUE_tenant", "core")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "context" {
+  values_env_prefix = "CONTEXT_VALUE_"
+
+  properties = {
+    namespace = { order = 1 }
+    tenant    = { order = 2 }
+    stage     = { order = 3 }
+  }
+
+  values = {
+    namespace = "cp"
+    tenant    = "plat"
+  }
+}
+
+data "context_config" "test" {}
+
+data "context_label" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_label.test", "rendered", "cp-plat-staging"),
+					resource.TestCheckResourceAttr("data.context_config.test", "value_sources.namespace", "provider"),
+					resource.TestCheckResourceAttr("data.context_config.test", "value_sources.tenant", "provider"),
+					resource.TestCheckResourceAttr("data.context_config.test", "value_sources.stage", "environment"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_nullLabelContext(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+  context = {
+    enabled             = true
+    namespace           = "cp"
+    tenant              = "core"
+    environment         = "ue1"
+    stage               = "prod"
+    name                = "app"
+    delimiter           = null
+    attributes          = []
+    tags                = {}
+    additional_tag_map  = {}
+    regex_replace_chars = null
+    label_order         = ["namespace", "tenant", "stage", "name"]
+    id_length_limit     = null
+    label_key_case      = null
+    label_value_case    = null
+    descriptor_formats = {
+      stack = {
+        format = "%v-%v"
+        labels = ["tenant", "stage"]
+      }
+    }
+    labels_as_tags = ["unset"]
+  }
+}
+
+provider "context" {
+  null_label_context = local.context
+
+  values = {
+    name = "api"
+  }
+}
+
+data "context_label" "test" {}
+
+data "context_label" "stack" {
+  format = "stack"
+}
+
+data "context_config" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_label.test", "rendered", "cp-core-prod-api"),
+					resource.TestCheckResourceAttr("data.context_label.stack", "rendered", "core-prod"),
+					resource.TestCheckResourceAttr("data.context_config.test", "value_sources.namespace", "null_label_context"),
+					resource.TestCheckResourceAttr("data.context_config.test", "value_sources.name", "provider"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_contextToken(t *testing.T) {
+	pc, err := model.NewProviderConfig(
+		[]model.Property{*model.NewProperty("namespace", model.WithOrder(1)), *model.NewProperty("stage", model.WithOrder(2)), *model.NewProperty("name", model.WithOrder(3))},
+		nil,
+		map[string]string{"namespace": "cp", "stage": "prod", "name": "network"},
+		model.WithDelimiter("_"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := pc.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "context" {
+  context_token = %q
+
+  values = {
+    name = "app"
+  }
+}
+
+data "context_label" "test" {}
+
+data "context_config" "test" {}`, token),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_label.test", "rendered", "cp_prod_app"),
+					resource.TestCheckResourceAttr("data.context_config.test", "value_sources.namespace", "context_token"),
+					resource.TestCheckResourceAttr("data.context_config.test", "value_sources.name", "provider"),
+				),
+			},
+			{
+				Config: `
+provider "context" {
+  context_token = "not a token"
+}
+
+data "context_config" "test" {}`,
+				ExpectError: regexp.MustCompile(`Invalid Context Token`),
 			},
 		},
 	})
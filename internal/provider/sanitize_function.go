@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudposse/terraform-provider-context/internal/model"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SanitizeFunction{}
+
+func NewSanitizeFunction() function.Function {
+	return &SanitizeFunction{}
+}
+
+// SanitizeFunction defines the function implementation.
+type SanitizeFunction struct{}
+
+func (f *SanitizeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "sanitize"
+}
+
+func (f *SanitizeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Removes every match of a regex from a string",
+		MarkdownDescription: "Removes every match of a regex from a string, exactly like `context_label` does with `replace_chars_regex`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "The string to sanitize.",
+			},
+			function.StringParameter{
+				Name:                "regex",
+				MarkdownDescription: "The regex matching the characters to remove. An empty regex leaves the string unchanged.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SanitizeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	var regex string
+
+	resp.Error = req.Arguments.Get(ctx, &value, &regex)
+	if resp.Error != nil {
+		return
+	}
+
+	sanitized, err := model.RedactLabel(value, regex)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("%s: %s", model.ErrInvalidRegex, err))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, sanitized)
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeFunction(t *testing.T) {
+	testCases := []struct {
+		value    string
+		regex    string
+		expected string
+	}{
+		{"bar-foo-baz", "o|a", "br-f-bz"},
+		{"cp_core.prod", "[^a-zA-Z0-9-]", "cpcoreprod"},
+		{"unchanged", "", "unchanged"},
+	}
+
+	for _, tc := range testCases {
+		result, funcErr := callTestFunction(NewSanitizeFunction(), types.StringUnknown(), types.StringValue(tc.value), types.StringValue(tc.regex))
+		assert.Nil(t, funcErr)
+		assert.Equal(t, types.StringValue(tc.expected), result)
+	}
+}
+
+func TestSanitizeFunctionInvalidRegex(t *testing.T) {
+	_, funcErr := callTestFunction(NewSanitizeFunction(), types.StringUnknown(), types.StringValue("foo"), types.StringValue("["))
+	assert.NotNil(t, funcErr)
+	assert.Equal(t, int64(1), *funcErr.FunctionArgument)
+	assert.Contains(t, funcErr.Text, "regex is invalid")
+}
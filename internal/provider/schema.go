@@ -1,20 +1,54 @@
 package provider
 
 import (
+	"github.com/cloudposse/terraform-provider-context/internal/model"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func getPropertiesSchema() schema.NestedAttributeObject {
 	return schema.NestedAttributeObject{
 		Attributes: map[string]schema.Attribute{
+			"allowed_value_descriptions": schema.MapAttribute{
+				MarkdownDescription: "A map of descriptions of the allowed values, keyed by value. Every key must be one of `allowed_values`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"allowed_values": schema.ListAttribute{
+				MarkdownDescription: "The values the property is limited to, e.g. `[\"dev\", \"staging\", \"prod\"]`. An empty value is only checked by `required`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"default": schema.StringAttribute{
+				MarkdownDescription: "The value of the property when no value is set for it. Conflicts with `derive_from`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("derive_from")),
+				},
+			},
+			"derive_from": schema.StringAttribute{
+				MarkdownDescription: "A Go template the value of the property is derived from when no value is set for it, e.g. `{{.namespace}}-{{.stage}}`. The template can use other values by name, including defaults and other derived values, and look a value up in one of the `lookups` or the built-in region catalogs with the `lookup` function, e.g. `{{lookup \"aws_region_short\" .region}}`. Conflicts with `default`.",
+				Optional:            true,
+			},
 			"include_in_tags": schema.BoolAttribute{
 				MarkdownDescription: "A flag to indicate if the property should be included in tags. If not set, defaults to true.",
 				Optional:            true,
 			},
+			"label_lookup": schema.StringAttribute{
+				MarkdownDescription: "The name of the lookup in `lookups`, or of a built-in region catalog such as `aws_region_short`, that replaces the value of the property in labels, e.g. to abbreviate `us-west-2` to `uw2`. The value is validated before it is replaced.",
+				Optional:            true,
+			},
 			"max_length": schema.Int64Attribute{
 				MarkdownDescription: "The maximum length of the property.",
 				Optional:            true,
@@ -29,10 +63,35 @@ func getPropertiesSchema() schema.NestedAttributeObject {
 					int64validator.AtLeast(0),
 				},
 			},
+			"order": schema.Int64Attribute{
+				MarkdownDescription: "The position of the property in the default property order, used when `property_order` is not set. Properties are sorted by order, then by name. Defaults to 0.",
+				Optional:            true,
+			},
 			"required": schema.BoolAttribute{
 				MarkdownDescription: "A flag to indicate if the property is required.",
 				Optional:            true,
 			},
+			"required_when": schema.MapAttribute{
+				MarkdownDescription: "Makes the property required when other properties have certain values. A map of property names to the values that make this property required, e.g. `{ namespace = [\"acme\", \"globex\"] }`. Each value is a regular expression that must match the whole value, so a plain value only matches itself. The property is required when every property in the map matches one of its values.",
+				Optional:            true,
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Validators: []validator.Map{
+					mapvalidator.ValueListsAre(listvalidator.SizeAtLeast(1)),
+				},
+			},
+			"severity": schema.MapAttribute{
+				MarkdownDescription: "The severity of the checks of the property, keyed by check, e.g. `{ validation_regex = \"warning\" }`. A check with the `warning` severity is reported as a warning and does not stop the label or tags from being rendered, so a new convention can be rolled out before it is enforced. Valid checks are: allowed_values, max_length, min_length, required, validation_regex. The `required` check also covers `required_when`. Valid severities are: error, warning. Checks default to `error`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.OneOf(model.ValidChecks...)),
+					mapvalidator.ValueStringsAre(stringvalidator.OneOf(model.ValidSeverities...)),
+				},
+			},
+			"tag_lookup": schema.StringAttribute{
+				MarkdownDescription: "The name of the lookup in `lookups`, or of a built-in region catalog such as `aws_region_short`, that replaces the value of the property in tags. The value is validated before it is replaced.",
+				Optional:            true,
+			},
 			"tags_key_case": schema.StringAttribute{
 				MarkdownDescription: "The case to use for the key of this property in tags. If not set, uses the provider's tags_key_case setting. Valid values are: none, camel, lower, snake, title, upper.",
 				Optional:            true,
@@ -58,10 +117,32 @@ func getPropertiesSchema() schema.NestedAttributeObject {
 func getPropertiesDSSchema() dsschema.NestedAttributeObject {
 	return dsschema.NestedAttributeObject{
 		Attributes: map[string]dsschema.Attribute{
+			"allowed_value_descriptions": dsschema.MapAttribute{
+				MarkdownDescription: "A map of descriptions of the allowed values, keyed by value.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"allowed_values": dsschema.ListAttribute{
+				MarkdownDescription: "The values the property is limited to, in the order they were declared.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"default": dsschema.StringAttribute{
+				MarkdownDescription: "The value of the property when no value is set for it.",
+				Optional:            true,
+			},
+			"derive_from": dsschema.StringAttribute{
+				MarkdownDescription: "The template the value of the property is derived from when no value is set for it.",
+				Optional:            true,
+			},
 			"include_in_tags": dsschema.BoolAttribute{
 				MarkdownDescription: "A flag to indicate if the property should be included in tags.",
 				Optional:            true,
 			},
+			"label_lookup": dsschema.StringAttribute{
+				MarkdownDescription: "The name of the lookup that replaces the value of the property in labels.",
+				Optional:            true,
+			},
 			"max_length": dsschema.Int64Attribute{
 				MarkdownDescription: "The maximum length of the property.",
 				Optional:            true,
@@ -70,10 +151,28 @@ func getPropertiesDSSchema() dsschema.NestedAttributeObject {
 				MarkdownDescription: "The minimum length of the property.",
 				Optional:            true,
 			},
+			"order": dsschema.Int64Attribute{
+				MarkdownDescription: "The position of the property in the default property order.",
+				Optional:            true,
+			},
 			"required": dsschema.BoolAttribute{
 				MarkdownDescription: "A flag to indicate if the property is required.",
 				Optional:            true,
 			},
+			"required_when": dsschema.MapAttribute{
+				MarkdownDescription: "A map of property names to the values that make the property required.",
+				Optional:            true,
+				ElementType:         types.ListType{ElemType: types.StringType},
+			},
+			"severity": dsschema.MapAttribute{
+				MarkdownDescription: "The severity of the checks of the property, keyed by check.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"tag_lookup": dsschema.StringAttribute{
+				MarkdownDescription: "The name of the lookup that replaces the value of the property in tags.",
+				Optional:            true,
+			},
 			"tags_key_case": dsschema.StringAttribute{
 				MarkdownDescription: "The case to use for the key of this property in tags.",
 				Optional:            true,
@@ -123,3 +222,141 @@ func GetProviderSchema() map[string]dsschema.Attribute {
 		// ... rest of the schema ...
 	}
 }
+
+// getContextDSSchema returns the schema of the optional serialized context accepted by data sources.
+func getContextDSSchema(description string) dsschema.StringAttribute {
+	return dsschema.StringAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+	}
+}
+
+func getExplainDSSchema() dsschema.BoolAttribute {
+	return dsschema.BoolAttribute{
+		MarkdownDescription: "Set to true to populate `explanation` with a trace of how the output was built from the context. Defaults to false.",
+		Optional:            true,
+	}
+}
+
+func getExplanationDSSchema() dsschema.SingleNestedAttribute {
+	return dsschema.SingleNestedAttribute{
+		MarkdownDescription: "A trace of how the output was built. Only set when `explain` is true.",
+		Computed:            true,
+		Attributes: map[string]dsschema.Attribute{
+			"properties": dsschema.ListNestedAttribute{
+				MarkdownDescription: "One entry per property, in the order they were considered.",
+				Computed:            true,
+				NestedObject: dsschema.NestedAttributeObject{
+					Attributes: map[string]dsschema.Attribute{
+						"included": dsschema.BoolAttribute{
+							MarkdownDescription: "Whether the property is part of the output.",
+							Computed:            true,
+						},
+						"name": dsschema.StringAttribute{
+							MarkdownDescription: "The name of the property.",
+							Computed:            true,
+						},
+						"reason": dsschema.StringAttribute{
+							MarkdownDescription: "Why the property was included or left out: `included`, `empty value`, `not in properties`, `not in property order`, `not in template` or `excluded from tags`.",
+							Computed:            true,
+						},
+						"removed": dsschema.StringAttribute{
+							MarkdownDescription: "The characters of the value that `replace_chars_regex` matches on its own. The regex is applied to the whole label, so see `removed` of the explanation for what was removed from the label.",
+							Computed:            true,
+						},
+						"source": dsschema.StringAttribute{
+							MarkdownDescription: "Where the value came from: `context_token`, `config_file`, `null_label_context`, `environment`, `provider`, `context_child`, `data_source`, `default`, `derived` or `unset`.",
+							Computed:            true,
+						},
+						"value": dsschema.StringAttribute{
+							MarkdownDescription: "The merged value of the property.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"removed": dsschema.StringAttribute{
+				MarkdownDescription: "The characters removed from the label by `replace_chars_regex`, which is applied to the whole label rather than to each value. Empty for tags and the context.",
+				Computed:            true,
+			},
+			"truncated": dsschema.BoolAttribute{
+				MarkdownDescription: "Whether the output was truncated to `max_length`.",
+				Computed:            true,
+			},
+			"untruncated": dsschema.StringAttribute{
+				MarkdownDescription: "The output before truncation.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func getLabelFormatsSchema() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"delimiter": schema.StringAttribute{
+				MarkdownDescription: "The delimiter to use between the properties of the label. Defaults to the provider's `delimiter`. Conflicts with `template`.",
+				Optional:            true,
+			},
+			"max_length": schema.Int64Attribute{
+				MarkdownDescription: "The maximum length of the label.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"properties": schema.ListAttribute{
+				MarkdownDescription: "The properties to include in the label, in order. Defaults to the provider's `property_order`. Conflicts with `template`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"template": schema.StringAttribute{
+				MarkdownDescription: "The template to render the label with, e.g. `{{.tenant}}-{{.stage}}`. Conflicts with `delimiter` and `properties`.",
+				Optional:            true,
+			},
+			"truncate": schema.BoolAttribute{
+				MarkdownDescription: "Truncate the label if it exceeds `max_length`. If false, an error is returned instead. Defaults to true.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func getValidationsSchema() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"condition": schema.StringAttribute{
+				MarkdownDescription: "A Go template over the values that renders `true` when they are valid and `false` when they are not, e.g. `{{ le (len (print .namespace .name)) 20 }}`. " +
+					"Besides the built-in functions, such as `eq`, `and`, `len` and `le`, the template can use `contains`, `hasPrefix`, `hasSuffix`, `matches` (a regex and a value) and `oneOf` (a value and its choices).",
+				Required: true,
+			},
+			"error_message": schema.StringAttribute{
+				MarkdownDescription: "The message reported when the condition is false. It is a Go template over the values too, e.g. `{{.namespace}}{{.name}} is longer than 20 characters`.",
+				Optional:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the rule, reported with its error message.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func getLookupsSchema() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"strict": schema.BoolAttribute{
+				MarkdownDescription: "Set to true to report a value that is not in `values` as an error. If false, such a value is used unchanged. Defaults to false.",
+				Optional:            true,
+			},
+			"values": schema.MapAttribute{
+				MarkdownDescription: "A map of the values of a property to the values that replace them, e.g. `{ \"us-west-2\" = \"uw2\" }`.",
+				Required:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
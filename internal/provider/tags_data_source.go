@@ -9,6 +9,7 @@ import (
 	"github.com/cloudposse/terraform-provider-context/pkg/cases"
 	mapHelpers "github.com/cloudposse/terraform-provider-context/pkg/map"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -17,7 +18,10 @@ import (
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
-var _ datasource.DataSource = &TagsDataSource{}
+var (
+	_ datasource.DataSource                   = &TagsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &TagsDataSource{}
+)
 
 func NewTagsDataSource() datasource.DataSource {
 	return &TagsDataSource{}
@@ -30,6 +34,10 @@ type TagsDataSource struct {
 
 // TagsDataSourceModel describes the data source data model.
 type TagsDataSourceModel struct {
+	Context       types.String `tfsdk:"context"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	Explain       types.Bool   `tfsdk:"explain"`
+	Explanation   types.Object `tfsdk:"explanation"`
 	Id            types.String `tfsdk:"id"`
 	Values        types.Map    `tfsdk:"values"`
 	Tags          types.Map    `tfsdk:"tags"`
@@ -38,6 +46,17 @@ type TagsDataSourceModel struct {
 	TagsAsList    types.List   `tfsdk:"tags_as_list"`
 }
 
+// isFullyKnown returns false when any of the settings used to create the tags is unknown, which happens at plan time
+// when they are computed from resources that are not created yet.
+func (m *TagsDataSourceModel) isFullyKnown() bool {
+	for _, v := range []attr.Value{m.Context, m.Enabled, m.TagsKeyCase, m.TagsValueCase, m.Values} {
+		if !framework.IsFullyKnown(v) {
+			return false
+		}
+	}
+	return true
+}
+
 func (d *TagsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_tags"
 }
@@ -48,6 +67,14 @@ func (d *TagsDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 		MarkdownDescription: "Tags data source",
 
 		Attributes: map[string]schema.Attribute{
+			"context": getContextDSSchema("A serialized context, like the `encoded` attribute of a `context_child` data source, to create the tags from. Defaults to the provider context."),
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Set to false to return empty tags without validating the values. Defaults to the provider's `enabled` setting.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"explain":     getExplainDSSchema(),
+			"explanation": getExplanationDSSchema(),
 			"tags": schema.MapAttribute{
 				MarkdownDescription: "Map of tags.",
 				Computed:            true,
@@ -104,14 +131,6 @@ func (d *TagsDataSource) Configure(ctx context.Context, req datasource.Configure
 	d.providerData = providerData
 }
 
-func (d *TagsDataSource) handleValidationErrors(resp *datasource.ReadResponse, errs []error) {
-	for _, err := range errs {
-		if err != nil {
-			resp.Diagnostics.AddError("Validation Error", err.Error())
-		}
-	}
-}
-
 func (d *TagsDataSource) getLocalValues(ctx context.Context, config *TagsDataSourceModel, resp *datasource.ReadResponse) map[string]string {
 	localValues, diags := framework.FromFrameworkMap[string](ctx, config.Values)
 	resp.Diagnostics.Append(diags...)
@@ -145,10 +164,32 @@ func (d *TagsDataSource) getLocalTagsValueCase(config *TagsDataSourceModel, resp
 	return nil
 }
 
+// setDisabledTags sets empty tags for a disabled context.
+func (d *TagsDataSource) setDisabledTags(config *TagsDataSourceModel) {
+	config.Tags = types.MapValueMust(types.StringType, map[string]attr.Value{})
+	config.TagsAsList = types.ListValueMust(types.MapType{ElemType: types.StringType}, []attr.Value{})
+	config.Id = types.StringValue(mapHelpers.HashMap(map[string]string{}))
+	config.Explanation = types.ObjectNull(model.ExplanationAttrTypes())
+}
+
+// ValidateConfig validates the known values of tags whose settings are not all known yet. Terraform defers reading
+// such a data source until apply, so this is the only chance to report invalid values at plan time.
+//
+//nolint:gocritic
+func (d *TagsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config TagsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.isFullyKnown() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateKnownValues(d.providerData, config.Context, config.Enabled, config.Values)...)
+}
+
 //nolint:revive
-func (d *TagsDataSource) setTags(ctx context.Context, config *TagsDataSourceModel, resp *datasource.ReadResponse, localValues map[string]string, localTagsKeyCase, localTagsValueCase *cases.Case) {
-	tags, errs := d.providerData.ProviderConfig.GetTags(localValues, localTagsKeyCase, localTagsValueCase)
-	d.handleValidationErrors(resp, errs)
+func (d *TagsDataSource) setTags(ctx context.Context, pc *model.ProviderConfig, config *TagsDataSourceModel, resp *datasource.ReadResponse, localValues map[string]string, localTagsKeyCase, localTagsValueCase *cases.Case) {
+	tags, explanation, errs := pc.ExplainTags(localValues, localTagsKeyCase, localTagsValueCase)
+	addValidationErrors(errs, &resp.Diagnostics, localValues, nil)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -160,14 +201,20 @@ func (d *TagsDataSource) setTags(ctx context.Context, config *TagsDataSourceMode
 	}
 	config.Tags = frameworkTags
 
+	config.Explanation, diags = explanation.ToFramework(ctx, config.Explain)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tagsAsHash := mapHelpers.HashMap(tags)
 	config.Id = types.StringValue(tagsAsHash)
 }
 
 //nolint:revive
-func (d *TagsDataSource) setTagsList(ctx context.Context, config *TagsDataSourceModel, resp *datasource.ReadResponse, localValues map[string]string, localTagsKeyCase, localTagsValueCase *cases.Case) {
-	tagsList, errs := d.providerData.ProviderConfig.GetTagsAsList(localValues, localTagsKeyCase, localTagsValueCase)
-	d.handleValidationErrors(resp, errs)
+func (d *TagsDataSource) setTagsList(ctx context.Context, pc *model.ProviderConfig, config *TagsDataSourceModel, resp *datasource.ReadResponse, localValues map[string]string, localTagsKeyCase, localTagsValueCase *cases.Case) {
+	tagsList, errs := pc.GetTagsAsList(localValues, localTagsKeyCase, localTagsValueCase)
+	addValidationErrors(errs, &resp.Diagnostics, localValues, nil)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -187,6 +234,20 @@ func (d *TagsDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 
+	pc, diags := getContextProviderConfig(d.providerData, config.Context)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	enabled := pc.GetMergedEnabled(config.Enabled.ValueBoolPointer())
+	config.Enabled = types.BoolValue(enabled)
+	if !enabled {
+		d.setDisabledTags(&config)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+		return
+	}
+
 	localValues := d.getLocalValues(ctx, &config, resp)
 	if resp.Diagnostics.HasError() {
 		return
@@ -202,12 +263,12 @@ func (d *TagsDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	d.setTags(ctx, &config, resp, localValues, localTagsKeyCase, localTagsValueCase)
+	d.setTags(ctx, pc, &config, resp, localValues, localTagsKeyCase, localTagsValueCase)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	d.setTagsList(ctx, &config, resp, localValues, localTagsKeyCase, localTagsValueCase)
+	d.setTagsList(ctx, pc, &config, resp, localValues, localTagsKeyCase, localTagsValueCase)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -146,6 +146,40 @@ data "context_tags" "upper" {
 	})
 }
 
+func TestAccTagsDataSource_disabled(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "context" {
+  properties = {
+    namespace = {
+      required = true
+    }
+  }
+
+  values = {
+    namespace = "test"
+  }
+}
+
+data "context_tags" "test" {
+  enabled = false
+  values = {
+    namespace = ""
+  }
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_tags.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("data.context_tags.test", "tags.%", "0"),
+					resource.TestCheckResourceAttr("data.context_tags.test", "tags_as_list.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccTagsDataSource_values(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -211,7 +245,7 @@ provider "context" {
 }
 
 data "context_tags" "test" {}`,
-				ExpectError: regexp.MustCompile(`(?s)Error running pre-apply plan: exit status 1\s+Error: Validation Error\s+with provider\["registry.terraform.io/hashicorp/context"\],\s+on terraform_plugin_test.tf line 12, in provider "context":\s+12: provider "context" {\s+value does not match regex: value TEST for property namespace does not match\s+\^\[a-z\]\+\$`),
+				ExpectError: regexp.MustCompile(`(?s)Error running pre-apply plan: exit status 1\s+Error: Value Does Not Match Regex\s+with provider\["registry.terraform.io/hashicorp/context"\].*namespace = "TEST".*value does not match regex: value TEST for property namespace does not match\s+\^\[a-z\]\+\$`),
 			},
 		},
 	})
@@ -406,3 +440,39 @@ provider "context" {
 data "context_tags" "test" {
 }
 `
+
+func TestAccTagsDataSource_explain(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "context" {
+  properties = {
+    name      = {}
+    namespace = { include_in_tags = false }
+  }
+
+  values = {
+    namespace = "cp"
+  }
+}
+
+data "context_tags" "test" {
+  explain = true
+  values = {
+    name = "example"
+  }
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.context_tags.test", "explanation.properties.#", "2"),
+					resource.TestCheckResourceAttr("data.context_tags.test", "explanation.properties.0.name", "name"),
+					resource.TestCheckResourceAttr("data.context_tags.test", "explanation.properties.0.source", "data_source"),
+					resource.TestCheckResourceAttr("data.context_tags.test", "explanation.properties.0.included", "true"),
+					resource.TestCheckResourceAttr("data.context_tags.test", "explanation.properties.1.name", "namespace"),
+					resource.TestCheckResourceAttr("data.context_tags.test", "explanation.properties.1.reason", "excluded from tags"),
+				),
+			},
+		},
+	})
+}
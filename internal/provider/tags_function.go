@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/cloudposse/terraform-provider-context/internal/model"
+	"github.com/cloudposse/terraform-provider-context/pkg/cases"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &TagsFunction{}
+
+// tagsFunctionOverrides are the attributes accepted in the overrides object of the tags function.
+var tagsFunctionOverrides = []string{"enabled", "tags_key_case", "tags_value_case", "values"}
+
+func NewTagsFunction() function.Function {
+	return &TagsFunction{}
+}
+
+// TagsFunction defines the function implementation.
+type TagsFunction struct{}
+
+func (f *TagsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "tags"
+}
+
+func (f *TagsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Renders tags from a serialized context",
+		MarkdownDescription: "Renders a map of tags from a serialized context, such as the `encoded` attribute of `context_config`, the same way the `context_tags` data source does. " +
+			"An optional overrides object accepts the `enabled`, `tags_key_case`, `tags_value_case` and `values` attributes of the data source.",
+		Parameters: []function.Parameter{getContextParameter()},
+		VariadicParameter: function.DynamicParameter{
+			Name:                "overrides",
+			MarkdownDescription: "Object with the values and settings to override when rendering the tags.",
+		},
+		Return: function.MapReturn{ElementType: types.StringType},
+	}
+}
+
+func (f *TagsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	pc, overrides, funcErr := getFunctionArguments(ctx, req, tagsFunctionOverrides)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	enabled, err := overrides.getBool("enabled")
+	if err != nil {
+		resp.Error = overrides.error(err.Error())
+		return
+	}
+	if !pc.GetMergedEnabled(enabled.ValueBoolPointer()) {
+		resp.Error = resp.Result.Set(ctx, map[string]string{})
+		return
+	}
+
+	values, tagsKeyCase, tagsValueCase, err := getTagsFunctionConfig(ctx, overrides)
+	if err != nil {
+		resp.Error = overrides.error(err.Error())
+		return
+	}
+
+	tags, errs := pc.GetTags(values, tagsKeyCase, tagsValueCase)
+	for _, err := range errs {
+		// Functions cannot return warnings, so failed checks with the warning severity are not reported
+		if model.IsWarning(err) {
+			continue
+		}
+		resp.Error = function.ConcatFuncErrors(resp.Error, overrides.error(err.Error()))
+	}
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, tags)
+}
+
+// getTagsFunctionConfig reads the values and cases from the overrides object of the tags function.
+func getTagsFunctionConfig(ctx context.Context, overrides functionOverrides) (map[string]string, *cases.Case, *cases.Case, error) {
+	frameworkValues, err := overrides.getStringMap("values")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	values := map[string]string{}
+	if !frameworkValues.IsNull() {
+		// Elements are checked to be strings by getStringMap, so no diagnostics are expected.
+		_ = frameworkValues.ElementsAs(ctx, &values, false)
+	}
+
+	tagsKeyCase, err := getFunctionCase(overrides, "tags_key_case")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	tagsValueCase, err := getFunctionCase(overrides, "tags_value_case")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return values, tagsKeyCase, tagsValueCase, nil
+}
+
+// getFunctionCase reads a case from the overrides object, returning nil when it is not set.
+func getFunctionCase(overrides functionOverrides, key string) (*cases.Case, error) {
+	value, err := overrides.getString(key)
+	if err != nil || value.IsNull() {
+		return nil, err
+	}
+
+	c, err := cases.FromString(value.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagsFunction(t *testing.T) {
+	f := NewTagsFunction()
+
+	result, funcErr := runTestFunction(t, f, types.MapUnknown(types.StringType))
+	assert.Nil(t, funcErr)
+	assert.Equal(t, types.MapValueMust(types.StringType, map[string]attr.Value{
+		"Namespace": types.StringValue("cp"),
+		"Tenant":    types.StringValue("core"),
+		"Stage":     types.StringValue("prod"),
+		"Name":      types.StringValue("example"),
+	}), result)
+}
+
+func TestTagsFunctionWithOverrides(t *testing.T) {
+	f := NewTagsFunction()
+
+	overrides := testObject(t, map[string]attr.Value{
+		"tags_key_case":   types.StringValue("upper"),
+		"tags_value_case": types.StringValue("upper"),
+		"values":          testObject(t, map[string]attr.Value{"Name": types.StringValue("testing")}),
+	})
+
+	result, funcErr := runTestFunction(t, f, types.MapUnknown(types.StringType), overrides)
+	assert.Nil(t, funcErr)
+	assert.Equal(t, types.MapValueMust(types.StringType, map[string]attr.Value{
+		"NAMESPACE": types.StringValue("CP"),
+		"TENANT":    types.StringValue("CORE"),
+		"STAGE":     types.StringValue("PROD"),
+		"NAME":      types.StringValue("TESTING"),
+	}), result)
+}
+
+func TestTagsFunctionInvalidCase(t *testing.T) {
+	f := NewTagsFunction()
+
+	overrides := testObject(t, map[string]attr.Value{"tags_key_case": types.StringValue("kebab")})
+
+	_, funcErr := runTestFunction(t, f, types.MapUnknown(types.StringType), overrides)
+	assert.NotNil(t, funcErr)
+	assert.Equal(t, int64(1), *funcErr.FunctionArgument)
+	assert.Contains(t, funcErr.Text, "unknown case: kebab")
+}
+
+func TestTagsFunctionValidationError(t *testing.T) {
+	f := NewTagsFunction()
+
+	overrides := testObject(t, map[string]attr.Value{
+		"values": testObject(t, map[string]attr.Value{"Namespace": types.StringValue("")}),
+	})
+
+	_, funcErr := runTestFunction(t, f, types.MapUnknown(types.StringType), overrides)
+	assert.NotNil(t, funcErr)
+	assert.Equal(t, int64(1), *funcErr.FunctionArgument)
+	assert.Contains(t, funcErr.Text, "property is required")
+}
+
+func TestTagsFunctionDisabled(t *testing.T) {
+	f := NewTagsFunction()
+
+	overrides := testObject(t, map[string]attr.Value{"enabled": types.BoolValue(false)})
+
+	result, funcErr := runTestFunction(t, f, types.MapUnknown(types.StringType), overrides)
+	assert.Nil(t, funcErr)
+	assert.Equal(t, types.MapValueMust(types.StringType, map[string]attr.Value{}), result)
+}
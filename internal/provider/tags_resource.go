@@ -0,0 +1,247 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudposse/terraform-provider-context/internal/framework"
+	"github.com/cloudposse/terraform-provider-context/internal/model"
+	"github.com/cloudposse/terraform-provider-context/pkg/cases"
+	mapHelpers "github.com/cloudposse/terraform-provider-context/pkg/map"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource              = &TagsResource{}
+	_ resource.ResourceWithConfigure = &TagsResource{}
+)
+
+func NewTagsResource() resource.Resource {
+	return &TagsResource{}
+}
+
+// TagsResource defines the resource implementation. Unlike the tags data source, the tags are only created when the
+// resource is created, so changes to the provider context do not change them.
+type TagsResource struct {
+	providerData *model.ProviderData
+}
+
+// TagsResourceModel describes the resource data model.
+type TagsResourceModel struct {
+	Context       types.String `tfsdk:"context"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	Id            types.String `tfsdk:"id"`
+	Keepers       types.Map    `tfsdk:"keepers"`
+	Tags          types.Map    `tfsdk:"tags"`
+	TagsAsList    types.List   `tfsdk:"tags_as_list"`
+	TagsKeyCase   types.String `tfsdk:"tags_key_case"`
+	TagsValueCase types.String `tfsdk:"tags_value_case"`
+	Values        types.Map    `tfsdk:"values"`
+}
+
+func (r *TagsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tags"
+}
+
+func (r *TagsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Tags resource. Creates tags like the `context_tags` data source when it is created and keeps them in state, " +
+			"so later changes to the provider context do not change them. The tags are only created again when an argument of the " +
+			"resource, such as `values` or `keepers`, changes.",
+
+		Attributes: map[string]schema.Attribute{
+			"context": schema.StringAttribute{
+				MarkdownDescription: "A serialized context, like the `encoded` attribute of a `context_child` data source, to create the tags from. Defaults to the provider context.",
+				Optional:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Set to false to create empty tags without validating the values. Defaults to the provider's `enabled` setting.",
+				Optional:            true,
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Tags identifier",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"keepers": getKeepersSchema(mapplanmodifier.RequiresReplace()),
+			"tags": schema.MapAttribute{
+				MarkdownDescription: "Map of tags.",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers:       []planmodifier.Map{mapplanmodifier.UseStateForUnknown()},
+			},
+			"tags_as_list": schema.ListAttribute{
+				MarkdownDescription: "List of tags in {Key='key', Value='value'} format.",
+				Computed:            true,
+				ElementType:         types.MapType{ElemType: types.StringType},
+				PlanModifiers:       []planmodifier.List{listplanmodifier.UseStateForUnknown()},
+			},
+			"tags_key_case": schema.StringAttribute{
+				MarkdownDescription: "The case to use for the keys of the tags. Valid values are: none, camel, lower, snake, title, upper.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(ValidCases...),
+				},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"tags_value_case": schema.StringAttribute{
+				MarkdownDescription: "The case to use for the values of the tags. Valid values are: none, camel, lower, snake, title, upper.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(ValidCases...),
+				},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"values": schema.MapAttribute{
+				MarkdownDescription: "Map of values to override or add to the context when creating the tags.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers:       []planmodifier.Map{mapplanmodifier.RequiresReplace()},
+			},
+		},
+	}
+}
+
+func (r *TagsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*model.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// getCase converts an optional case attribute to the model.
+func getCase(value types.String, attribute string) (*cases.Case, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if value.IsNull() {
+		return nil, diags
+	}
+
+	c, err := cases.FromString(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root(attribute), fmt.Sprintf("Failed to convert %s to model", attribute), err.Error())
+		return nil, diags
+	}
+	return &c, diags
+}
+
+// createTags creates the tags and the list of tags from the context.
+func (r *TagsResource) createTags(ctx context.Context, pc *model.ProviderConfig, plan *TagsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tags, tagsList := map[string]string{}, []map[string]string{}
+	if pc.GetMergedEnabled(plan.Enabled.ValueBoolPointer()) {
+		values, d := framework.FromFrameworkMap[string](ctx, plan.Values)
+		diags.Append(d...)
+		keyCase, d := getCase(plan.TagsKeyCase, "tags_key_case")
+		diags.Append(d...)
+		valueCase, d := getCase(plan.TagsValueCase, "tags_value_case")
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+
+		var errs []error
+		tags, errs = pc.GetTags(values, keyCase, valueCase)
+		addValidationErrors(errs, &diags, values, nil)
+		tagsList, errs = pc.GetTagsAsList(values, keyCase, valueCase)
+		addValidationErrors(errs, &diags, values, nil)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	var d diag.Diagnostics
+	plan.Tags, d = types.MapValueFrom(ctx, types.StringType, tags)
+	diags.Append(d...)
+	plan.TagsAsList, d = types.ListValueFrom(ctx, types.MapType{ElemType: types.StringType}, tagsList)
+	diags.Append(d...)
+	plan.Id = types.StringValue(mapHelpers.HashMap(tags))
+	return diags
+}
+
+//nolint:gocritic
+func (r *TagsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan TagsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pc, diags := getContextProviderConfig(r.providerData, plan.Context)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.createTags(ctx, pc, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created tags resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read keeps the tags in state as they are. The tags are not created again, so they do not follow the provider context.
+//
+//nolint:gocritic
+func (r *TagsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Trace(ctx, "read tags resource")
+}
+
+// Update is only called when no argument requiring replacement changed, so the tags in state are kept.
+//
+//nolint:gocritic
+func (r *TagsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state TagsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = state.Id
+	plan.Tags = state.Tags
+	plan.TagsAsList = state.TagsAsList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the tags from state. There is nothing else to clean up.
+//
+//nolint:gocritic
+func (r *TagsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Trace(ctx, "deleted tags resource")
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccTagsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTagsResourceCfg("prod", "app"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("context_tags.test", "tags.Stage", "prod"),
+					resource.TestCheckResourceAttr("context_tags.test", "tags.Name", "app"),
+					resource.TestCheckResourceAttr("context_tags.test", "tags_as_list.0.Key", "Name"),
+				),
+			},
+			// A change to the provider context does not change the tags
+			{
+				Config: testAccTagsResourceCfg("staging", "app"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("context_tags.test", "tags.Stage", "prod"),
+				),
+			},
+			// A change to the values creates the tags again
+			{
+				Config: testAccTagsResourceCfg("staging", "api"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("context_tags.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("context_tags.test", "tags.Stage", "staging"),
+					resource.TestCheckResourceAttr("context_tags.test", "tags.Name", "api"),
+				),
+			},
+		},
+	})
+}
+
+func testAccTagsResourceCfg(stage, name string) string {
+	return fmt.Sprintf(`
+provider "context" {
+  properties = {
+    stage = {}
+    name  = {}
+  }
+
+  values = {
+    stage = %q
+  }
+}
+
+resource "context_tags" "test" {
+  values = {
+    name = %q
+  }
+}
+`, stage, name)
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/cloudposse/terraform-provider-context/internal/model"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &TruncateWithHashFunction{}
+
+func NewTruncateWithHashFunction() function.Function {
+	return &TruncateWithHashFunction{}
+}
+
+// TruncateWithHashFunction defines the function implementation.
+type TruncateWithHashFunction struct{}
+
+func (f *TruncateWithHashFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "truncate_with_hash"
+}
+
+func (f *TruncateWithHashFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Truncates a string and appends a hash of the original value",
+		MarkdownDescription: "Truncates a string that is longer than `max_length` by replacing its end with a CRC16 checksum " +
+			"of the full string, exactly like `context_label` does when `truncate` is enabled. Strings that fit are returned unchanged.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "The string to truncate.",
+			},
+			function.Int64Parameter{
+				Name:                "max_length",
+				MarkdownDescription: "The maximum length of the result. A value of 0 disables truncation.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *TruncateWithHashFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	var maxLength int64
+
+	resp.Error = req.Arguments.Get(ctx, &value, &maxLength)
+	if resp.Error != nil {
+		return
+	}
+
+	if maxLength < 0 {
+		resp.Error = function.NewArgumentFuncError(1, ErrNegativeMaxLength.Error())
+		return
+	}
+
+	truncated, err := model.TruncateLabel(value, int(maxLength), true)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, truncated)
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateWithHashFunction(t *testing.T) {
+	testCases := []struct {
+		value     string
+		maxLength int64
+		expected  string
+	}{
+		{"foo-bar-baz", 10, "foo-ba6094"},
+		{"foo-bar-baz", 11, "foo-bar-baz"},
+		{"foo-bar-baz", 0, "foo-bar-baz"},
+		{"cp/core/prod/example", 10, "cp/co46903"},
+	}
+
+	for _, tc := range testCases {
+		result, funcErr := callTestFunction(NewTruncateWithHashFunction(), types.StringUnknown(), types.StringValue(tc.value), types.Int64Value(tc.maxLength))
+		assert.Nil(t, funcErr)
+		assert.Equal(t, types.StringValue(tc.expected), result)
+	}
+}
+
+func TestTruncateWithHashFunctionNegativeMaxLength(t *testing.T) {
+	_, funcErr := callTestFunction(NewTruncateWithHashFunction(), types.StringUnknown(), types.StringValue("foo"), types.Int64Value(-1))
+	assert.NotNil(t, funcErr)
+	assert.Equal(t, int64(1), *funcErr.FunctionArgument)
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"errors"
+
+	"github.com/cloudposse/terraform-provider-context/internal/model"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// validationErrorSummaries holds the summary of the diagnostic for each kind of validation error.
+var validationErrorSummaries = []struct {
+	err     error
+	summary string
+}{
+	{model.ErrPropertyRequired, "Missing Required Value"},
+	{model.ErrValueTooShort, "Value Too Short"},
+	{model.ErrValueTooLong, "Value Too Long"},
+	{model.ErrRegexMismatch, "Value Does Not Match Regex"},
+	{model.ErrValueNotAllowed, "Value Not Allowed"},
+	{model.ErrValueNotInLookup, "Value Not In Lookup"},
+	{model.ErrInvalidRegex, "Invalid Validation Regex"},
+	{model.ErrLabelTooLong, "Label Too Long"},
+	{model.ErrDeriveFailed, "Failed To Derive Value"},
+	{model.ErrValidationRuleFailed, "Validation Rule Failed"},
+	{model.ErrInvalidValidationRule, "Invalid Validation Rule"},
+}
+
+// getValidationErrorSummary returns the summary of the diagnostic for a validation error.
+func getValidationErrorSummary(err error) string {
+	for _, s := range validationErrorSummaries {
+		if errors.Is(err, s.err) {
+			return s.summary
+		}
+	}
+	return "Validation Error"
+}
+
+// getValidationErrorPath returns the attribute the validation error is reported on, if the error belongs to a value
+// in the given values or, for an invalid validation regex, to a property in the given properties.
+func getValidationErrorPath(err error, values map[string]string, properties map[string]bool) (path.Path, bool) {
+	var propertyErr *model.PropertyError
+	if !errors.As(err, &propertyErr) {
+		return path.Empty(), false
+	}
+
+	if errors.Is(err, model.ErrInvalidRegex) {
+		return path.Root("properties").AtMapKey(propertyErr.Property), properties[propertyErr.Property]
+	}
+
+	_, ok := values[propertyErr.Property]
+	return path.Root("values").AtMapKey(propertyErr.Property), ok
+}
+
+// addValidationErrors adds a diagnostic for each validation error. Errors of a value set in the given values are
+// reported on that value in the values attribute and errors of the definition of a property set in the given
+// properties are reported on that property in the properties attribute. Other errors, such as those of values that
+// come from the provider context, are reported without an attribute. Failed checks with the warning severity are
+// reported as warnings.
+func addValidationErrors(errs []error, diags *diag.Diagnostics, values map[string]string, properties map[string]bool) {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		summary := getValidationErrorSummary(err)
+		p, ok := getValidationErrorPath(err, values, properties)
+		switch {
+		case model.IsWarning(err) && ok:
+			diags.AddAttributeWarning(p, summary, err.Error())
+		case model.IsWarning(err):
+			diags.AddWarning(summary, err.Error())
+		case ok:
+			diags.AddAttributeError(p, summary, err.Error())
+		default:
+			diags.AddError(summary, err.Error())
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cloudposse/terraform-provider-context/internal/model"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddValidationErrors(t *testing.T) {
+	errs := []error{}
+	errs = append(errs, model.NewProperty("namespace", model.WithMinLength(3)).Validate("t").Errors()...)
+	errs = append(errs, model.NewProperty("stage", model.WithValidationRegex("^[a-z]+$")).Validate("DEV").Errors()...)
+	errs = append(errs, model.NewProperty("tenant", model.WithRequired()).Validate("").Errors()...)
+	errs = append(errs, model.NewProperty("name", model.WithValidationRegex("[")).Validate("example").Errors()...)
+	errs = append(errs, errors.New("other error"), nil)
+
+	var diags diag.Diagnostics
+	addValidationErrors(errs, &diags, map[string]string{"namespace": "t", "stage": "DEV"}, map[string]bool{"name": true})
+
+	assert.Len(t, diags, 5)
+
+	assert.Equal(t, "Value Too Short", diags[0].Summary())
+	assert.Equal(t, path.Root("values").AtMapKey("namespace"), diags[0].(diag.DiagnosticWithPath).Path())
+
+	assert.Equal(t, "Value Does Not Match Regex", diags[1].Summary())
+	assert.Equal(t, path.Root("values").AtMapKey("stage"), diags[1].(diag.DiagnosticWithPath).Path())
+
+	// The value is not set in the given values, so there is no attribute to report the error on
+	assert.Equal(t, "Missing Required Value", diags[2].Summary())
+	_, ok := diags[2].(diag.DiagnosticWithPath)
+	assert.False(t, ok)
+
+	assert.Equal(t, "Invalid Validation Regex", diags[3].Summary())
+	assert.Equal(t, path.Root("properties").AtMapKey("name"), diags[3].(diag.DiagnosticWithPath).Path())
+
+	assert.Equal(t, "Validation Error", diags[4].Summary())
+	assert.Equal(t, "other error", diags[4].Detail())
+}
+
+func TestAddValidationErrorsLabelTooLong(t *testing.T) {
+	var diags diag.Diagnostics
+	addValidationErrors([]error{model.ErrLabelTooLong}, &diags, nil, nil)
+
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "Label Too Long", diags[0].Summary())
+}
+
+func TestAddValidationErrorsValidationRule(t *testing.T) {
+	pc, err := model.NewProviderConfig([]model.Property{}, []string{}, map[string]string{},
+		model.WithValidationRules([]model.ValidationRule{{Name: "stage", Condition: `{{ ne .stage "" }}`, ErrorMessage: "stage is required"}}))
+	assert.NoError(t, err)
+
+	var diags diag.Diagnostics
+	addValidationErrors(pc.ValidateProperties(map[string]string{"stage": ""}), &diags, map[string]string{"stage": ""}, nil)
+
+	// A rule spans several values, so it is not reported on any of them
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "Validation Rule Failed", diags[0].Summary())
+	assert.Equal(t, "validation rule failed: stage: stage is required", diags[0].Detail())
+	_, ok := diags[0].(diag.DiagnosticWithPath)
+	assert.False(t, ok)
+}
+
+func TestAddValidationErrorsWarning(t *testing.T) {
+	p := model.NewProperty("stage", model.WithValidationRegex("^[a-z]+$"),
+		model.WithSeverities(map[string]model.Severity{model.CheckValidationRegex: model.SeverityWarning}))
+	errs := p.Validate("DEV").Errors()
+
+	var diags diag.Diagnostics
+	addValidationErrors(errs, &diags, map[string]string{"stage": "DEV"}, nil)
+	addValidationErrors(errs, &diags, nil, nil)
+
+	assert.False(t, diags.HasError())
+	assert.Equal(t, 2, diags.WarningsCount())
+	assert.Equal(t, "Value Does Not Match Regex", diags[0].Summary())
+	assert.Equal(t, path.Root("values").AtMapKey("stage"), diags[0].(diag.DiagnosticWithPath).Path())
+	_, ok := diags[1].(diag.DiagnosticWithPath)
+	assert.False(t, ok)
+}
@@ -22,7 +22,13 @@ func TruncateWithHash(input string, maxLength int) string {
 
 	// Calculate the CRC16 checksum
 	checksum := crc16.Checksum(data, table)
-	hashLength := len(strconv.FormatUint(uint64(checksum), base10))
+	hash := strconv.FormatUint(uint64(checksum), base10)
+	hashLength := len(hash)
+
+	// If there is no room for any of the input, return as much of the hash as fits
+	if maxLength <= hashLength {
+		return hash[:max(maxLength, 0)]
+	}
 
 	truncated := fmt.Sprintf("%s%d", input[:maxLength-hashLength], checksum)
 
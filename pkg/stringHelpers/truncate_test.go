@@ -16,6 +16,9 @@ func TestTruncateAndHash(t *testing.T) {
 		{"12345", 5, "12345"},
 		{"abcdefghijklmnopqrstuvwxyz", 10, "abcde21474"},
 		{"foo-bar-baz", 10, "foo-ba6094"},
+		{"foo-bar-baz", 4, "6094"},
+		{"foo-bar-baz", 2, "60"},
+		{"foo-bar-baz", 0, ""},
 	}
 
 	for _, test := range tests {